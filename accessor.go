@@ -0,0 +1,85 @@
+package copy
+
+import (
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+func (c *copier) copyGetters(fromValue reflect.Value, toValue reflect.Value, matched map[string]bool) []error {
+	var errs []error
+
+	fromValue = addressable(fromValue)
+	toType := toValue.Type()
+
+	for i := 0; i < toType.NumField(); i++ {
+		toField := toType.Field(i)
+
+		if matched[toField.Name] || !toField.IsExported() || parseFieldTag(toField).ignore {
+			continue
+		}
+
+		getter, ok := methodByName(fromValue, GetterPrefix+toField.Name)
+
+		if !ok || getter.Type().NumIn() != 0 || getter.Type().NumOut() != 1 {
+			continue
+		}
+
+		matched[toField.Name] = true
+
+		v := getter.Call(nil)[0]
+		fc := c.at(toField.Name)
+
+		if c.when != nil && !c.when(fc.path, v) {
+			continue
+		}
+
+		if _, err := fc.copyValue(v, toValue.Field(i)); err != nil {
+			errs = append(errs, &FieldError{Field: toField.Name, Err: err})
+		}
+	}
+
+	return errs
+}
+
+// addressable returns reflectValue, or an addressable copy of it, so that
+// methods with pointer receivers can be looked up.
+func addressable(reflectValue reflect.Value) reflect.Value {
+	if reflectValue.CanAddr() {
+		return reflectValue
+	}
+
+	v := reflect.New(reflectValue.Type()).Elem()
+	v.Set(reflectValue)
+
+	return v
+}
+
+func methodByName(reflectValue reflect.Value, name string) (reflect.Value, bool) {
+	method := reflectValue.Addr().MethodByName(name)
+
+	return method, method.IsValid()
+}
+
+func (c *copier) copySetter(fromValue reflect.Value, toValue reflect.Value, name string) (bool, error) {
+	if SetterPrefix == "" || c.dryRun || !toValue.CanAddr() || !fromValue.CanInterface() {
+		return false, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	setter := toValue.Addr().MethodByName(SetterPrefix + string(unicode.ToUpper(r)) + name[size:])
+
+	if !setter.IsValid() || setter.Type().NumIn() != 1 {
+		return false, nil
+	}
+
+	v := reflect.New(setter.Type().In(0)).Elem()
+
+	if ok, err := c.copyValue(fromValue, v); !ok {
+		return false, err
+	}
+
+	setter.Call([]reflect.Value{v})
+
+	return true, nil
+}
@@ -0,0 +1,115 @@
+package copy
+
+import (
+	"testing"
+)
+
+type getterSource struct {
+	name string
+	age  int
+}
+
+func (s getterSource) GetName() string {
+	return s.name
+}
+
+func (s *getterSource) GetAge() int {
+	return s.age
+}
+
+func (s getterSource) GetBad(x int) int {
+	return x
+}
+
+func TestGetterPrefix(t *testing.T) {
+	type D struct {
+		Name string
+		Age  string
+		Bad  int
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		from   any
+		want   D
+	}{
+		{"disabled by default", "", getterSource{name: "a", age: 3}, D{}},
+		{"value source", "Get", getterSource{name: "a", age: 3}, D{Name: "a", Age: "3"}},
+		{"pointer source", "Get", &getterSource{name: "b", age: 4}, D{Name: "b", Age: "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &GetterPrefix, tt.prefix)
+
+			var d D
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+type setterDest struct {
+	username string
+	age      int
+}
+
+func (d *setterDest) SetUsername(v string) {
+	d.username = v
+}
+
+func (d *setterDest) SetAge(v int) {
+	d.age = v
+}
+
+func TestSetterPrefix(t *testing.T) {
+	type S struct {
+		Username string
+		Age      string
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		from   any
+		opts   []Option
+		want   setterDest
+	}{
+		{"disabled by default", "", S{Username: "a", Age: "7"}, nil, setterDest{}},
+		{"unmatched source fields", "Set", S{Username: "a", Age: "7"}, nil, setterDest{username: "a", age: 7}},
+		{"mapped onto unexported field", "Set", struct{ Login string }{"l"}, []Option{WithMapping(map[string]string{"Login": "username"})}, setterDest{username: "l"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &SetterPrefix, tt.prefix)
+
+			var d setterDest
+
+			if err := CopyE(tt.from, &d, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+
+	setVar(t, &SetterPrefix, "Set")
+
+	var nested struct{ D setterDest }
+
+	Copy(struct{ D struct{ Username string } }{struct{ Username string }{"x"}}, &nested)
+
+	if nested.D.username != "x" {
+		t.Fatalf("nested = %+v", nested)
+	}
+}
@@ -0,0 +1,99 @@
+package copy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var (
+	bufferType  = reflect.TypeOf(bytes.Buffer{})
+	builderType = reflect.TypeOf(strings.Builder{})
+)
+
+func isBytes(reflectType reflect.Type) bool {
+	return (reflectType.Kind() == reflect.Slice || reflectType.Kind() == reflect.Array) && reflectType.Elem().Kind() == reflect.Uint8
+}
+
+func encodeBytes(fromValue reflect.Value) string {
+	b := make([]byte, fromValue.Len())
+
+	for i := range b {
+		b[i] = byte(fromValue.Index(i).Uint())
+	}
+
+	switch BytesEncoding {
+	case "hex":
+		return hex.EncodeToString(b)
+	case "base64":
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	return string(b)
+}
+
+func decodeBytes(s string, toType reflect.Type) (reflect.Value, bool, error) {
+	b := []byte(s)
+
+	var err error
+
+	switch BytesEncoding {
+	case "hex":
+		b, err = hex.DecodeString(s)
+	case "base64":
+		b, err = base64.StdEncoding.DecodeString(s)
+	}
+
+	if err != nil {
+		return reflect.Value{}, false, nil
+	}
+
+	var v reflect.Value
+
+	if toType.Kind() == reflect.Array {
+		if len(b) != toType.Len() {
+			return reflect.Value{}, false, fmt.Errorf("%w: cannot decode %d bytes into %s", ErrLength, len(b), toType)
+		}
+
+		v = reflect.New(toType).Elem()
+	} else {
+		v = reflect.MakeSlice(toType, len(b), len(b))
+	}
+
+	for i := range b {
+		v.Index(i).SetUint(uint64(b[i]))
+	}
+
+	return v, true, nil
+}
+
+func writeBuffer(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if toValue.Type() != bufferType && toValue.Type() != builderType || !toValue.CanAddr() {
+		return false, nil
+	}
+
+	var b []byte
+
+	switch {
+	case fromValue.Kind() == reflect.String:
+		b = []byte(fromValue.String())
+	case isBytes(fromValue.Type()):
+		b = make([]byte, fromValue.Len())
+
+		for i := range b {
+			b[i] = byte(fromValue.Index(i).Uint())
+		}
+	default:
+		return false, nil
+	}
+
+	if _, err := toValue.Addr().Interface().(io.Writer).Write(b); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
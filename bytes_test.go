@@ -0,0 +1,87 @@
+package copy
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBytesEncoding(t *testing.T) {
+	hash := [4]byte{0xde, 0xad, 0xbe, 0xef}
+
+	tests := []struct {
+		name     string
+		encoding string
+		from     any
+		to       any
+		want     any
+		wantErr  error
+	}{
+		{"hex slice into string", "hex", struct{ V []byte }{hash[:]}, &struct{ V string }{}, &struct{ V string }{"deadbeef"}, nil},
+		{"hex string into slice", "hex", struct{ V string }{"deadbeef"}, &struct{ V []byte }{}, &struct{ V []byte }{hash[:]}, nil},
+		{"hex array into string", "hex", struct{ V [4]byte }{hash}, &struct{ V string }{}, &struct{ V string }{"deadbeef"}, nil},
+		{"hex string into array", "hex", struct{ V string }{"deadbeef"}, &struct{ V [4]byte }{}, &struct{ V [4]byte }{hash}, nil},
+		{"hex length mismatch", "hex", struct{ V string }{"dead"}, &struct{ V [4]byte }{}, &struct{ V [4]byte }{}, ErrLength},
+		{"base64 slice into string", "base64", struct{ V []byte }{hash[:]}, &struct{ V string }{}, &struct{ V string }{"3q2+7w=="}, nil},
+		{"base64 string into array", "base64", struct{ V string }{"3q2+7w=="}, &struct{ V [4]byte }{}, &struct{ V [4]byte }{hash}, nil},
+		{"raw slice into string", "", struct{ V []byte }{[]byte("hi")}, &struct{ V string }{}, &struct{ V string }{"hi"}, nil},
+		{"raw string into slice", "", struct{ V string }{"hi"}, &struct{ V []byte }{}, &struct{ V []byte }{[]byte("hi")}, nil},
+		{"raw length mismatch", "", struct{ V string }{"hi"}, &struct{ V [4]byte }{}, &struct{ V [4]byte }{}, ErrLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &BytesEncoding, tt.encoding)
+
+			if err := CopyE(tt.from, tt.to); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %+v, want %+v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestBytesEncodingDefault(t *testing.T) {
+	var s string
+
+	if err := CopyE([4]byte{0xde, 0xad, 0xbe, 0xef}, &s); err != nil || s != "deadbeef" {
+		t.Fatalf("got %q, %v, want hex", s, err)
+	}
+}
+
+func TestWriteIntoBuffers(t *testing.T) {
+	type Log struct {
+		Msg *bytes.Buffer
+		B   *strings.Builder
+		V   bytes.Buffer
+	}
+
+	var l Log
+
+	from := struct {
+		Msg string
+		B   []byte
+		V   string
+	}{"hi", []byte("yo"), "v"}
+
+	if err := CopyE(from, &l); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.Msg.String() != "hi" || l.B.String() != "yo" || l.V.String() != "v" {
+		t.Fatalf("got %q %q %q", l.Msg, l.B, &l.V)
+	}
+
+	if err := CopyE(map[string]any{"Msg": " there"}, &l); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.Msg.String() != "hi there" {
+		t.Fatalf("appended %q", l.Msg)
+	}
+}
@@ -0,0 +1,87 @@
+package copy
+
+import (
+	"reflect"
+	"sync"
+)
+
+type converterKey struct {
+	fromType reflect.Type
+	toType   reflect.Type
+}
+
+var (
+	convertersMu   sync.RWMutex
+	pathConverters = map[string]func(reflect.Value, reflect.Value) (bool, error){}
+	typeConverters = map[converterKey]func(reflect.Value, reflect.Value) (bool, error){}
+)
+
+func RegisterPathConverter(path string, converter func(from reflect.Value, to reflect.Value) (bool, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	if converter == nil {
+		delete(pathConverters, path)
+
+		return
+	}
+
+	pathConverters[path] = converter
+}
+
+// RegisterConverter registers a converter used whenever a value of
+// fromType is copied into a value of toType. Pointers are resolved first:
+// nil destination pointers are allocated and the converter receives the
+// values they point to, so wrapper types such as *wrapperspb.StringValue
+// only need a converter between string and wrapperspb.StringValue, plus
+// one for the reverse direction.
+func RegisterConverter(fromType reflect.Type, toType reflect.Type, converter func(from reflect.Value, to reflect.Value) (bool, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	key := converterKey{indirectType(fromType), indirectType(toType)}
+
+	if converter == nil {
+		delete(typeConverters, key)
+
+		return
+	}
+
+	typeConverters[key] = converter
+}
+
+func hasConverters() bool {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	return len(pathConverters) > 0 || len(typeConverters) > 0
+}
+
+func (c *copier) convert(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	convertersMu.RLock()
+	pathConverter, ok := pathConverters[c.path]
+	convertersMu.RUnlock()
+
+	if ok && c.path != "" {
+		if ok, err := pathConverter(fromValue, toValue); ok || err != nil {
+			return ok, err
+		}
+	}
+
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !toValue.IsValid() {
+		return false, nil
+	}
+
+	convertersMu.RLock()
+	typeConverter, ok := typeConverters[converterKey{fromValue.Type(), toValue.Type()}]
+	convertersMu.RUnlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	return typeConverter(fromValue, toValue)
+}
@@ -0,0 +1,111 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegisterPathConverter(t *testing.T) {
+	type Order struct {
+		Total, Tax float64
+	}
+
+	type Doc struct {
+		Order Order
+		Items []Order
+	}
+
+	type DocOut struct {
+		Order struct{ Total, Tax int }
+		Items []struct{ Total int }
+	}
+
+	RegisterPathConverter("Order.Total", func(from reflect.Value, to reflect.Value) (bool, error) {
+		to.SetInt(int64(from.Float() * 100))
+
+		return true, nil
+	})
+
+	RegisterPathConverter("Items.1.Total", func(from reflect.Value, to reflect.Value) (bool, error) {
+		to.SetInt(-1)
+
+		return true, nil
+	})
+
+	t.Cleanup(func() {
+		RegisterPathConverter("Order.Total", nil)
+		RegisterPathConverter("Items.1.Total", nil)
+	})
+
+	var d DocOut
+
+	if err := CopyE(Doc{Order: Order{Total: 1.5, Tax: 2}, Items: []Order{{Total: 3}, {Total: 4}}}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Order.Total != 150 || d.Order.Tax != 2 || d.Items[0].Total != 3 || d.Items[1].Total != -1 {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+type stringValue struct {
+	Value string
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(stringValue{}), func(from reflect.Value, to reflect.Value) (bool, error) {
+		to.FieldByName("Value").SetString(from.String())
+
+		return true, nil
+	})
+
+	RegisterConverter(reflect.TypeOf(stringValue{}), reflect.TypeOf(""), func(from reflect.Value, to reflect.Value) (bool, error) {
+		to.SetString(from.FieldByName("Value").String())
+
+		return true, nil
+	})
+
+	t.Cleanup(func() {
+		RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(stringValue{}), nil)
+		RegisterConverter(reflect.TypeOf(stringValue{}), reflect.TypeOf(""), nil)
+	})
+
+	var wrapped struct {
+		Name *stringValue
+		Nick *stringValue
+	}
+
+	if err := CopyE(struct {
+		Name string
+		Nick *string
+	}{"n", nil}, &wrapped); err != nil {
+		t.Fatal(err)
+	}
+
+	if wrapped.Name == nil || wrapped.Name.Value != "n" || wrapped.Nick != nil {
+		t.Fatalf("wrapped %+v", wrapped)
+	}
+
+	var plain struct {
+		Name string
+		Nick string
+	}
+
+	if err := CopyE(wrapped, &plain); err != nil {
+		t.Fatal(err)
+	}
+
+	if plain.Name != "n" || plain.Nick != "" {
+		t.Fatalf("unwrapped %+v", plain)
+	}
+
+	var ptr struct{ Name *string }
+
+	if err := CopyE(wrapped, &ptr); err != nil {
+		t.Fatal(err)
+	}
+
+	if ptr.Name == nil || *ptr.Name != "n" {
+		t.Fatalf("unwrapped into pointer %+v", ptr)
+	}
+}
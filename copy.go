@@ -1,287 +1,1728 @@
 package copy
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
+	"unicode/utf8"
+	"unsafe"
 )
 
 var (
 	DateTimeLayout = time.DateTime
 	TimeZone       = "Asia/Shanghai"
+	TagKey         = "copy"
+
+	// FallbackTimeZone is used when TimeZone cannot be loaded. With
+	// StrictTimeZone such conversions fail with ErrTimeZone instead.
+	FallbackTimeZone = time.UTC
+	StrictTimeZone   = false
+
+	JSONTagFallback    = false
+	TagToTag           = false
+	JSONMapKeys        = false
+	CheckedConversions = false
+	StringToNumber     = true
+	NumberToString     = true
+
+	// CheckedFloat reports an ErrOverflow error when narrowing a float64
+	// into a float32 overflows to infinity, or, when FloatTolerance is
+	// positive, changes the value by more than that relative amount.
+	CheckedFloat   = false
+	FloatTolerance = 0.0
+
+	NaNToken    = "NaN"
+	PosInfToken = "+Inf"
+	NegInfToken = "-Inf"
+
+	// AllowUnexported copies unexported struct fields by writing through
+	// unsafe pointers. It bypasses Go's visibility rules, so only use it
+	// for types you own, such as test fixtures or clones of the same type.
+	AllowUnexported = false
+
+	// BytesEncoding selects how byte slices and arrays are written to and
+	// read from strings: "hex" by default, "base64", or "" to copy the raw
+	// bytes.
+	BytesEncoding = "hex"
+
+	RawJSON = false
+
+	// ScalarBroadcast changes how a single value is copied into a slice.
+	// By default the destination becomes a one-element slice holding the
+	// converted value; with ScalarBroadcast, every element of a non-empty
+	// destination is overwritten with it instead.
+	ScalarBroadcast = false
+
+	AutoLayout = false
+
+	UnixUnit     = time.Second
+	FloatSeconds = false
+
+	NormalizeTimeZone = false
+	KeepSourceZone    = false
+
+	TimeFormatter func(time.Time) string
+	TimeParser    func(string) (time.Time, error)
+
+	// GobFallback round-trips values through encoding/gob when a struct,
+	// map or slice cannot be copied field by field, either because the
+	// reflective copy failed or because the values are opaque structs. It
+	// produces an independent deep copy but is much slower.
+	GobFallback = false
+
+	InPlaceSlice = false
+
+	ParallelThreshold = 0
+	ParallelWorkers   = 0
+
+	SliceSeparator = ""
+
+	MaxSliceLen    = 0
+	StrictSliceLen = false
+
+	EnvKeyMatch = false
+
+	IndexedMapKeys = false
+
+	StrictSettable = false
+
+	StrictKeys     = false
+	StrictMatching = false
+
+	ZeroUnmatched = false
+
+	NilSliceAsEmpty = false
+	NilMapAsEmpty   = false
+
+	// GetterPrefix and SetterPrefix enable accessor methods such as GetName
+	// and SetName for fields that have no direct match. Both are off by
+	// default.
+	GetterPrefix = ""
+	SetterPrefix = ""
+
+	// RuneStrings copies int32 and uint8 values to and from strings as
+	// characters rather than numbers. rune and byte are aliases of those
+	// types and cannot be told apart by reflection, so this applies to
+	// every int32 and uint8 value while it is enabled.
+	RuneStrings = false
+
+	BoolStrings map[bool]string
+
+	UseStringer = false
+
+	Recursive = false
+
+	SentinelZero map[reflect.Type]any
+
+	SkipNonImplementing = true
+)
+
+var (
+	ErrInvalidDestination = errors.New("copy: destination must be a non-nil pointer")
+	ErrOverflow           = errors.New("copy: value out of range")
+	ErrLength             = errors.New("copy: length mismatch")
+	ErrNotSettable        = errors.New("copy: destination field cannot be set")
+	ErrMapKey             = errors.New("copy: map key cannot be converted")
+	ErrAmbiguous          = errors.New("copy: several source fields match the destination field")
+	ErrTooLong            = errors.New("copy: slice too long")
+	ErrRequired           = errors.New("copy: required field not set")
+	ErrNotImplemented     = errors.New("copy: value does not implement the destination interface")
+	ErrTimeZone           = errors.New("copy: time zone cannot be loaded")
 )
 
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 type Service interface {
 	CopyValue(reflect.Value, reflect.Value) bool
 }
 
+type ServiceE interface {
+	CopyValueE(reflect.Value, reflect.Value) (bool, error)
+}
+
+type CopyFromer interface {
+	CopyFrom(any) bool
+}
+
 type DefaultService struct{}
 
 var CopyService Service = DefaultService{}
 
 func (s DefaultService) CopyValue(fromValue reflect.Value, toValue reflect.Value) bool {
-	fromValue = indirectValue(fromValue)
+	ok, _ := s.CopyValueE(fromValue, toValue)
+
+	return ok
+}
+
+// CopyValueE copies fromValue into toValue. Values of the same kind
+// always convert even when their named types differ: bool to bool, any
+// integer or float kind to any other, and string to string, so
+// `type A bool` copies into `type B bool` and `type I int` into
+// `type J int64`. Strings and numbers convert to each other by parsing
+// and formatting unless StringToNumber or NumberToString is disabled.
+func (s DefaultService) CopyValueE(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if ok, err := s.convert(fromValue, toValue); ok || err != nil {
+		return ok, err
+	}
+
+	return copySQL(fromValue, toValue)
+}
+
+func (s DefaultService) convert(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if assignInterface(fromValue, indirectValue(toValue)) {
+		return true, nil
+	}
+
+	if copyError(fromValue, toValue) {
+		return true, nil
+	}
+
+	fromValue = indirectInterface(fromValue)
 	toValue = indirectValue(toValue)
 
 	if !fromValue.IsValid() {
-		return false
+		return false, nil
 	}
 
 	if !toValue.IsValid() {
-		return false
+		return false, nil
 	}
 
 	fromType := indirectType(fromValue.Type())
 	toType := indirectType(toValue.Type())
 
 	if fromType.AssignableTo(toType) {
+		if NormalizeTimeZone && fromType == reflect.TypeOf(time.Time{}) && toType == fromType && fromValue.CanInterface() {
+			if err := strictTimeZone(); err != nil {
+				return false, err
+			}
+
+			toValue.Set(reflect.ValueOf(fromValue.Interface().(time.Time).In(getTimeZone())))
+
+			return true, nil
+		}
+
 		toValue.Set(fromValue)
 
-		return true
+		return true, nil
+	}
+
+	if ok, err := writeBuffer(fromValue, toValue); ok || err != nil {
+		return ok, err
+	}
+
+	if RawJSON {
+		if ok, err := copyJSON(fromValue, toValue); ok || err != nil {
+			return ok, err
+		}
+	}
+
+	if ok, err := copySplit(fromValue, toValue, SliceSeparator); ok || err != nil {
+		return ok, err
 	}
 
 	if toType.Kind() == reflect.String {
+		if UseStringer && fromType.Kind() != reflect.Struct && fromValue.CanInterface() {
+			if v, ok := fromValue.Interface().(fmt.Stringer); ok {
+				toValue.Set(reflect.ValueOf(v.String()).Convert(toType))
+
+				return true, nil
+			}
+		}
+
+		if RuneStrings && (fromType.Kind() == reflect.Int32 || fromType.Kind() == reflect.Uint8) {
+			if fromType.Kind() == reflect.Int32 {
+				toValue.Set(reflect.ValueOf(string(rune(fromValue.Int()))).Convert(toType))
+			} else {
+				toValue.Set(reflect.ValueOf(string([]byte{byte(fromValue.Uint())})).Convert(toType))
+			}
+
+			return true, nil
+		}
+
+		if isNumber(fromType.Kind()) && !NumberToString {
+			return false, nil
+		}
+
 		switch fromType.Kind() {
+		case reflect.String:
+			toValue.Set(fromValue.Convert(toType))
+
+			return true, nil
 		case reflect.Bool:
-			toValue.Set(reflect.ValueOf(strconv.FormatBool(fromValue.Bool())).Convert(toType))
+			v, ok := BoolStrings[fromValue.Bool()]
 
-			return true
+			if !ok {
+				v = strconv.FormatBool(fromValue.Bool())
+			}
+
+			toValue.Set(reflect.ValueOf(v).Convert(toType))
+
+			return true, nil
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			toValue.Set(reflect.ValueOf(strconv.FormatInt(fromValue.Int(), 10)).Convert(toType))
 
-			return true
+			return true, nil
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			toValue.Set(reflect.ValueOf(strconv.FormatUint(fromValue.Uint(), 10)).Convert(toType))
 
-			return true
+			return true, nil
 		case reflect.Float32:
-			toValue.Set(reflect.ValueOf(strconv.FormatFloat(fromValue.Float(), 'f', -1, 32)).Convert(toType))
+			toValue.Set(reflect.ValueOf(formatFloat(fromValue.Float(), 32)).Convert(toType))
 
-			return true
+			return true, nil
 		case reflect.Float64:
-			toValue.Set(reflect.ValueOf(strconv.FormatFloat(fromValue.Float(), 'f', -1, 64)).Convert(toType))
+			toValue.Set(reflect.ValueOf(formatFloat(fromValue.Float(), 64)).Convert(toType))
 
-			return true
+			return true, nil
+		case reflect.Slice, reflect.Array:
+			if isBytes(fromType) {
+				toValue.Set(reflect.ValueOf(encodeBytes(fromValue)).Convert(toType))
+
+				return true, nil
+			}
 		case reflect.Struct:
 			if fromValue.CanInterface() {
 				if v, ok := fromValue.Interface().(time.Time); ok {
-					toValue.Set(reflect.ValueOf(v.In(getTimeZone()).Format(DateTimeLayout)).Convert(toType))
+					if err := strictTimeZone(); err != nil {
+						return false, err
+					}
+
+					toValue.Set(reflect.ValueOf(formatTime(v)).Convert(toType))
 
-					return true
+					return true, nil
 				}
 
 				if v, ok := fromValue.Interface().(fmt.Stringer); ok {
 					toValue.Set(reflect.ValueOf(v.String()).Convert(toType))
 
-					return true
+					return true, nil
 				}
 			}
 		}
 
-		return false
+		return false, nil
+	}
+
+	if toType.Kind() == reflect.Slice && !isCollection(fromType.Kind()) && !(fromType.Kind() == reflect.String && isBytes(toType)) {
+		// a single value copied into a slice
+
+		v := reflect.New(toType.Elem()).Elem()
+
+		ok, err := copyValue(fromValue, v)
+
+		if !ok {
+			return false, err
+		}
+
+		if ScalarBroadcast && toValue.Len() > 0 {
+			for i := 0; i < toValue.Len(); i++ {
+				toValue.Index(i).Set(v)
+			}
+		} else {
+			toValue.Set(reflect.Append(reflect.MakeSlice(toType, 0, 1), v))
+		}
+
+		return true, nil
 	}
 
 	if fromType.Kind() == reflect.String {
+		if RuneStrings && toType.Kind() == reflect.Int32 && utf8.RuneCountInString(fromValue.String()) == 1 {
+			r, _ := utf8.DecodeRuneInString(fromValue.String())
+			toValue.Set(reflect.ValueOf(r).Convert(toType))
+
+			return true, nil
+		}
+
+		if RuneStrings && toType.Kind() == reflect.Uint8 && len(fromValue.String()) == 1 {
+			toValue.Set(reflect.ValueOf(fromValue.String()[0]).Convert(toType))
+
+			return true, nil
+		}
+
+		if isNumber(toType.Kind()) && !StringToNumber {
+			return false, nil
+		}
+
 		switch toType.Kind() {
 		case reflect.Bool:
+			for v, text := range BoolStrings {
+				if text == fromValue.String() {
+					toValue.Set(reflect.ValueOf(v).Convert(toType))
+
+					return true, nil
+				}
+			}
+
 			if v, err := strconv.ParseBool(fromValue.String()); err == nil {
 				toValue.Set(reflect.ValueOf(v).Convert(toType))
 
-				return true
+				return true, nil
 			}
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			if v, err := strconv.ParseInt(fromValue.String(), 10, 0); err == nil {
 				toValue.Set(reflect.ValueOf(v).Convert(toType))
 
-				return true
+				return true, nil
 			}
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			if v, err := strconv.ParseUint(fromValue.String(), 10, 0); err == nil {
 				toValue.Set(reflect.ValueOf(v).Convert(toType))
 
-				return true
+				return true, nil
 			}
 		case reflect.Float32, reflect.Float64:
-			if v, err := strconv.ParseFloat(fromValue.String(), 64); err == nil {
+			if v, err := parseFloat(fromValue.String()); err == nil {
 				toValue.Set(reflect.ValueOf(v).Convert(toType))
 
-				return true
+				return true, nil
+			}
+		case reflect.Slice, reflect.Array:
+			if isBytes(toType) {
+				v, ok, err := decodeBytes(fromValue.String(), toType)
+
+				if ok {
+					toValue.Set(v)
+				}
+
+				return ok, err
 			}
 		case reflect.Struct:
 			v := reflect.New(toType).Elem()
 
 			if v.CanInterface() {
 				if _, ok := v.Interface().(time.Time); ok {
-					if t, err := time.ParseInLocation(DateTimeLayout, fromValue.String(), getTimeZone()); err == nil {
+					if err := strictTimeZone(); err != nil {
+						return false, err
+					}
+
+					if t, ok := parseTime(fromValue.String()); ok {
 						toValue.Set(reflect.ValueOf(t).Convert(toType))
 					}
 
-					return true
+					return true, nil
 				}
 			}
 		}
 
-		return false
+		return false, nil
+	}
+
+	if FloatSeconds && fromType == reflect.TypeOf(time.Time{}) && toValue.CanFloat() && fromValue.CanInterface() {
+		toValue.SetFloat(floatSeconds(fromValue.Interface().(time.Time)))
+
+		return true, nil
+	}
+
+	if isNumber(fromType.Kind()) && toType == reflect.TypeOf(time.Time{}) {
+		if err := strictTimeZone(); err != nil {
+			return false, err
+		}
+
+		toValue.Set(reflect.ValueOf(unixTime(fromValue)))
+
+		return true, nil
 	}
 
 	if fromValue.CanConvert(toType) {
+		if CheckedConversions {
+			if err := checkConversion(fromValue, toType); err != nil {
+				return false, err
+			}
+		}
+
+		if CheckedFloat {
+			if err := checkFloat(fromValue, toType); err != nil {
+				return false, err
+			}
+		}
+
 		toValue.Set(fromValue.Convert(toType))
 
-		return true
+		return true, nil
 	}
 
-	return false
+	return false, nil
+}
+
+func Copy(from any, to any, opts ...Option) {
+	_ = CopyE(from, to, opts...)
 }
 
-func Copy(from any, to any) {
+func CopyE(from any, to any, opts ...Option) error {
 	fromValue := reflect.ValueOf(from)
 	toValue := reflect.ValueOf(to)
 
 	if !fromValue.IsValid() {
-		return
+		return nil
 	}
 
-	if toValue.Type().Kind() != reflect.Pointer {
-		return
+	if !toValue.IsValid() || toValue.Type().Kind() != reflect.Pointer || toValue.IsNil() {
+		return ErrInvalidDestination
 	}
 
-	fromValue = indirectValue(fromValue)
-	toValue = indirectValue(toValue)
+	return CopyValues(fromValue, toValue, opts...)
+}
 
-	fromType := indirectType(fromValue.Type())
-	toType := indirectType(toValue.Type())
+func CopyValues(fromValue reflect.Value, toValue reflect.Value, opts ...Option) error {
+	if !fromValue.IsValid() {
+		return nil
+	}
 
-	if fromType.Kind() == reflect.Slice && toType.Kind() == reflect.Slice {
-		// slice to slice
+	if !toValue.IsValid() {
+		return ErrInvalidDestination
+	}
 
-		for i := 0; i < fromValue.Len(); i++ {
-			if !fromValue.Index(i).IsValid() {
-				continue
-			}
+	if toValue.Kind() == reflect.Pointer && toValue.IsNil() {
+		if !toValue.CanSet() {
+			return ErrInvalidDestination
+		}
 
-			v := reflect.New(toType.Elem()).Elem()
+		toValue.Set(reflect.New(toValue.Type().Elem()))
+	}
 
-			if CopyService.CopyValue(fromValue.Index(i), v) {
-				toValue.Set(reflect.Append(toValue, v))
-			}
+	if toValue.Kind() != reflect.Pointer && !toValue.CanSet() && (toValue.Kind() != reflect.Map || toValue.IsNil()) {
+		return ErrInvalidDestination
+	}
+
+	if copyFrom(fromValue, toValue) {
+		return nil
+	}
+
+	if _, ok := CopyService.(DefaultService); ok {
+		// the struct branch below would copy sql.NullTime and time.Time field by field
+
+		if ok, err := copySQL(fromValue, toValue); ok || err != nil {
+			return err
 		}
-	} else if fromType.Kind() == reflect.Struct && toType.Kind() == reflect.Struct {
-		// struct to struct
+	}
 
-		for i := 0; i < fromType.NumField(); i++ {
-			fromField := fromType.Field(i)
+	return newCopier(opts).copyValues(fromValue, toValue)
+}
 
-			if toField, ok := toType.FieldByName(fromField.Name); ok {
-				fromFieldValue := fromValue.FieldByName(fromField.Name)
-				toFieldValue := toValue.FieldByName(fromField.Name)
+func (c *copier) copyValues(fromValue reflect.Value, toValue reflect.Value) error {
+	var errs []error
 
-				if !toFieldValue.CanSet() {
-					continue
-				}
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
 
-				if toField.Type.Kind() == reflect.Pointer && toFieldValue.IsNil() {
-					toFieldValue.Set(reflect.New(indirectType(toField.Type)))
-				}
+	if !fromValue.IsValid() || !toValue.IsValid() {
+		return nil
+	}
+
+	fromType := indirectType(fromValue.Type())
+	toType := indirectType(toValue.Type())
+
+	if fromType.Kind() != reflect.Map && (toType.Kind() == reflect.Struct || toType.Kind() == reflect.Map) {
+		if m, ok := orderedMap(fromValue); ok {
+			fromValue, fromType = m, m.Type()
+		}
+	}
+
+	if exceedsMaxSliceLen(fromValue) {
+		if StrictSliceLen {
+			return fmt.Errorf("%w: %d elements exceed MaxSliceLen %d", ErrTooLong, fromValue.Len(), MaxSliceLen)
+		}
+
+		fromValue = fromValue.Slice(0, MaxSliceLen)
+	}
 
-				CopyService.CopyValue(fromFieldValue, toFieldValue)
+	if isList(fromType.Kind()) && toType.Kind() == reflect.Slice {
+		if fromType.Kind() == reflect.Slice && fromValue.IsNil() {
+			if NilSliceAsEmpty && toValue.IsNil() {
+				toValue.Set(reflect.MakeSlice(toType, 0, 0))
 			}
+
+			return nil
 		}
-	} else if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Map {
-		// map to map
 
 		if toValue.IsNil() {
+			toValue.Set(reflect.MakeSlice(toType, 0, fromValue.Len()))
+		}
+	}
+
+	if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Map && fromValue.IsNil() {
+		if NilMapAsEmpty && toValue.IsNil() {
 			toValue.Set(reflect.MakeMap(toType))
 		}
 
-		kv := fromValue.MapRange()
+		return nil
+	}
 
-		for kv.Next() {
-			k := reflect.New(toType.Key()).Elem()
+	if fromType == toType && c.fieldsUnchanged() && fastCopy(fromValue, toValue) {
+		return nil
+	}
+
+	if isList(fromType.Kind()) && toType.Kind() == reflect.Slice {
+		// slice to slice
+
+		if ParallelThreshold > 0 && fromValue.Len() > ParallelThreshold && !InPlaceSlice {
+			return errors.Join(c.copyParallel(fromValue, toValue)...)
+		}
 
-			if !CopyService.CopyValue(kv.Key(), k) {
+		for i := 0; i < fromValue.Len(); i++ {
+			if !fromValue.Index(i).IsValid() {
 				continue
 			}
 
-			v := reflect.New(toType.Elem()).Elem()
+			ic := c.at(strconv.Itoa(i))
+
+			if InPlaceSlice && i < toValue.Len() {
+				if _, err := ic.copyValue(fromValue.Index(i), toValue.Index(i)); err != nil {
+					errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+				}
 
-			if !CopyService.CopyValue(kv.Value(), v) {
 				continue
 			}
 
-			toValue.SetMapIndex(k, v)
-		}
-	} else if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Struct {
-		// map to struct
+			v := reflect.New(toType.Elem()).Elem()
 
-		kv := fromValue.MapRange()
+			ok, err := ic.copyValue(fromValue.Index(i), v)
 
-		for kv.Next() {
-			if toField, ok := toType.FieldByName(kv.Key().String()); ok {
-				toFieldValue := toValue.FieldByName(kv.Key().String())
+			if !ok && err == nil && !SkipNonImplementing {
+				err = checkImplements(fromValue.Index(i), toType.Elem())
+			}
 
-				if !toFieldValue.CanSet() {
-					continue
-				}
+			if err != nil {
+				errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+			}
 
-				if toField.Type.Kind() == reflect.Pointer && toFieldValue.IsNil() {
-					toFieldValue.Set(reflect.New(indirectType(toField.Type)))
-				}
+			if ok {
+				toValue.Set(reflect.Append(toValue, v))
+			}
+		}
+	} else if isList(fromType.Kind()) && toType.Kind() == reflect.Array {
+		// slice to array
+
+		if fromValue.Len() > toValue.Len() {
+			errs = append(errs, ErrLength)
+		}
 
-				CopyService.CopyValue(kv.Value(), toFieldValue)
+		for i := 0; i < fromValue.Len() && i < toValue.Len(); i++ {
+			if _, err := c.at(strconv.Itoa(i)).copyValue(fromValue.Index(i), toValue.Index(i)); err != nil {
+				errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
 			}
 		}
-	} else if fromType.Kind() == reflect.Struct && toType.Kind() == reflect.Map {
-		// struct to map
+	} else if isList(fromType.Kind()) && isSet(toType) {
+		// slice to set
 
 		if toValue.IsNil() {
 			toValue.Set(reflect.MakeMap(toType))
 		}
 
-		for i := 0; i < fromType.NumField(); i++ {
-			fromField := fromType.Field(i)
-
+		for i := 0; i < fromValue.Len(); i++ {
 			k := reflect.New(toType.Key()).Elem()
 
-			if !CopyService.CopyValue(reflect.ValueOf(fromField.Name), k) {
-				continue
-			}
+			ok, err := c.at(strconv.Itoa(i)).copyValue(fromValue.Index(i), k)
 
-			v := reflect.New(toType.Elem()).Elem()
+			if err != nil {
+				errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+			}
 
-			if !CopyService.CopyValue(fromValue.FieldByName(fromField.Name), v) {
-				continue
+			if ok {
+				toValue.SetMapIndex(k, reflect.Zero(toType.Elem()))
 			}
+		}
+	} else if fromType.Kind() == reflect.Struct && toType.Kind() == reflect.Struct {
+		// struct to struct
 
-			toValue.SetMapIndex(k, v)
+		if AllowUnexported && !fromValue.CanAddr() {
+			v := reflect.New(fromType).Elem()
+			v.Set(fromValue)
+			fromValue = v
 		}
-	} else {
-		// value to value
 
-		CopyService.CopyValue(fromValue, toValue)
-	}
-}
+		matched := map[string]bool{}
 
-func getTimeZone() *time.Location {
-	if v, err := time.LoadLocation(TimeZone); err == nil {
-		return v
-	}
+		extras, hasExtras := inlineField(toType)
 
-	return time.UTC
-}
+		if hasExtras {
+			matched[extras.Name] = true
+		}
 
-func indirectValue(reflectValue reflect.Value) reflect.Value {
-	for reflectValue.Kind() == reflect.Pointer {
-		reflectValue = reflectValue.Elem()
-	}
+		var ctx CopyContext
 
-	return reflectValue
-}
+		hook, hasHook := c.afterCopier(toValue)
 
-func indirectType(reflectType reflect.Type) reflect.Type {
+		if hasHook {
+			ctx.Fields = map[string]string{}
+		}
+
+		matches := c.structMatches(fromType, toType)
+
+		chosen, ambiguous := c.structChoices(matches)
+		errs = append(errs, ambiguous...)
+
+		for _, m := range matches {
+			fromField, fromTag := m.from, m.fromTag
+
+			fromFieldValue, err := fromValue.FieldByIndexErr(fromField.Index)
+
+			if err != nil {
+				continue
+			}
+
+			if m.ok {
+				toField := m.to
+
+				if name, ok := chosen[toField.Name]; ok && name != fromField.Name {
+					continue
+				}
+
+				matched[toField.Name] = true
+
+				if hasHook {
+					ctx.Fields[toField.Name] = fromField.Name
+				}
+
+				toFieldValue, ok := fieldByIndex(toValue, toField.Index)
+
+				if !ok {
+					continue
+				}
+
+				if parseFieldTag(toField).hasOption("omitzero") && isZero(fromFieldValue) || c.skipMerge(fromFieldValue, toFieldValue) {
+					continue
+				}
+
+				fc := c.at(toField.Name)
+
+				if c.when != nil && !c.when(fc.path, fromFieldValue) {
+					continue
+				}
+
+				if AllowUnexported {
+					fromFieldValue = exposeValue(fromFieldValue)
+					toFieldValue = exposeValue(toFieldValue)
+				}
+
+				if !fromFieldValue.CanInterface() {
+					continue
+				}
+
+				if !toFieldValue.CanSet() {
+					if ok, err := fc.copySetter(fromFieldValue, toValue, toField.Name); ok || err != nil {
+						if err != nil {
+							errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+						}
+
+						continue
+					}
+
+					if StrictSettable {
+						errs = append(errs, &FieldError{Field: toField.Name, Err: ErrNotSettable})
+					}
+
+					continue
+				}
+
+				if _, err := fc.copyField(fromFieldValue, toFieldValue, fromTag, parseFieldTag(toField)); err != nil {
+					errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+				}
+			} else if ok, err := c.at(fromField.Name).copySetter(fromFieldValue, toValue, fromField.Name); err != nil {
+				errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+			} else if !ok && hasExtras && fromField.IsExported() && !fromField.Anonymous {
+				if err := c.copyExtra(toValue.FieldByIndex(extras.Index), fromTag.name, fromFieldValue); err != nil {
+					errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+				}
+			} else if !ok {
+				ctx.Skipped = append(ctx.Skipped, fromField.Name)
+			}
+		}
+
+		if GetterPrefix != "" && !c.dryRun {
+			errs = append(errs, c.copyGetters(fromValue, toValue, matched)...)
+		}
+
+		if ZeroUnmatched {
+			c.zeroUnmatched(toValue, matched)
+		}
+
+		if NilSliceAsEmpty || NilMapAsEmpty {
+			fillEmpty(toValue)
+		}
+
+		errs = append(errs, checkRequired(toValue, matched)...)
+
+		if hasHook {
+			if err := hook.AfterCopy(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	} else if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Map {
+		// map to map
+
+		if toValue.IsNil() {
+			toValue.Set(reflect.MakeMap(toType))
+		}
+
+		kv := fromValue.MapRange()
+
+		for kv.Next() {
+			key := kv.Key()
+
+			if c.keyTransform != nil && key.CanInterface() {
+				key = reflect.ValueOf(c.keyTransform(key.Interface()))
+			}
+
+			fc := c.at(keyString(key))
+
+			if c.when != nil && !c.when(fc.path, kv.Value()) {
+				continue
+			}
+
+			k := reflect.New(toType.Key()).Elem()
+
+			if ok, err := c.copyKey(key, k); !ok {
+				if err != nil {
+					errs = append(errs, &FieldError{Field: fmt.Sprint(kv.Key()), Err: err})
+				}
+
+				continue
+			}
+
+			if c.skipMerge(kv.Value(), toValue.MapIndex(k)) {
+				continue
+			}
+
+			v := reflect.New(toType.Elem()).Elem()
+
+			if ok, err := fc.copyValue(kv.Value(), v); !ok {
+				if err != nil {
+					errs = append(errs, &FieldError{Field: fmt.Sprint(kv.Key()), Err: err})
+				}
+
+				continue
+			}
+
+			toValue.SetMapIndex(k, v)
+		}
+	} else if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Struct {
+		// map to struct
+
+		matched := map[string]bool{}
+
+		extras, hasExtras := inlineField(toType)
+
+		if hasExtras {
+			matched[extras.Name] = true
+		}
+
+		var ctx CopyContext
+
+		hook, hasHook := c.afterCopier(toValue)
+
+		if hasHook {
+			ctx.Fields = map[string]string{}
+		}
+
+		chosen, ambiguous := c.mapChoices(fromValue, toType)
+		errs = append(errs, ambiguous...)
+
+		kv := fromValue.MapRange()
+
+		for kv.Next() {
+			key := keyString(kv.Key())
+			source := key
+
+			if name, mapped := c.mapping[key]; mapped {
+				key = name
+			}
+
+			if toField, ok := fieldByMapKey(toType, kv.Key(), key); ok {
+				if name, ok := chosen[toField.Name]; ok && name != source {
+					continue
+				}
+
+				matched[toField.Name] = true
+
+				if hasHook {
+					ctx.Fields[toField.Name] = source
+				}
+
+				toFieldValue, ok := fieldByIndex(toValue, toField.Index)
+
+				if !ok || !toFieldValue.CanSet() {
+					if StrictSettable {
+						errs = append(errs, &FieldError{Field: toField.Name, Err: ErrNotSettable})
+					}
+
+					continue
+				}
+
+				if parseFieldTag(toField).hasOption("omitzero") && isZero(kv.Value()) || c.skipMerge(kv.Value(), toFieldValue) {
+					continue
+				}
+
+				fc := c.at(toField.Name)
+
+				if c.when != nil && !c.when(fc.path, kv.Value()) {
+					continue
+				}
+
+				if _, err := fc.copyField(kv.Value(), toFieldValue, fieldTag{}, parseFieldTag(toField)); err != nil {
+					errs = append(errs, &FieldError{Field: key, Err: err})
+				}
+			} else if hasExtras {
+				if err := c.copyExtra(toValue.FieldByIndex(extras.Index), source, kv.Value()); err != nil {
+					errs = append(errs, &FieldError{Field: source, Err: err})
+				}
+			} else {
+				ctx.Skipped = append(ctx.Skipped, source)
+			}
+		}
+
+		if ZeroUnmatched {
+			c.zeroUnmatched(toValue, matched)
+		}
+
+		if NilSliceAsEmpty || NilMapAsEmpty {
+			fillEmpty(toValue)
+		}
+
+		errs = append(errs, checkRequired(toValue, matched)...)
+
+		if hasHook {
+			if err := hook.AfterCopy(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	} else if fromType.Kind() == reflect.Struct && toType.Kind() == reflect.Map {
+		// struct to map
+
+		if toValue.IsNil() {
+			toValue.Set(reflect.MakeMap(toType))
+		}
+
+		written := map[string]bool{}
+
+		extras, hasExtras := inlineField(fromType)
+
+		for i := 0; i < fromType.NumField(); i++ {
+			fromField := fromType.Field(i)
+			fromTag := parseFieldTag(fromField)
+
+			if fromTag.ignore || !fromField.IsExported() || c.only != nil && !c.only[fromField.Name] {
+				continue
+			}
+
+			name := fromTag.name
+
+			if JSONMapKeys {
+				jsonTag := parseTag(fromField, "json")
+
+				if jsonTag.ignore || jsonTag.hasOption("omitempty") && fromValue.Field(i).IsZero() {
+					continue
+				}
+
+				if _, ok := fromField.Tag.Lookup("json"); ok {
+					name = jsonTag.name
+				}
+			}
+
+			if mapped, ok := c.mapping[fromField.Name]; ok {
+				name = mapped
+			}
+
+			if hasExtras && i == extras.Index[0] {
+				continue
+			}
+
+			written[name] = true
+
+			if err := c.copyMapEntry(fromValue.Field(i), toValue, name); err != nil {
+				errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+			}
+		}
+
+		if hasExtras {
+			// spread the inline map into the top level
+
+			kv := fromValue.Field(extras.Index[0]).MapRange()
+
+			for kv.Next() {
+				if name := kv.Key().String(); !written[name] {
+					if err := c.copyMapEntry(kv.Value(), toValue, name); err != nil {
+						errs = append(errs, &FieldError{Field: name, Err: err})
+					}
+				}
+			}
+		}
+	} else {
+		// value to value
+
+		if _, err := c.copyValue(fromValue, toValue); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *copier) copyMapEntry(fromValue reflect.Value, toValue reflect.Value, name string) error {
+	fc := c.at(name)
+
+	if c.when != nil && !c.when(fc.path, fromValue) {
+		return nil
+	}
+
+	k := reflect.New(toValue.Type().Key()).Elem()
+
+	if ok, err := c.copyKey(reflect.ValueOf(name), k); !ok {
+		return err
+	}
+
+	if c.skipMerge(fromValue, toValue.MapIndex(k)) {
+		return nil
+	}
+
+	v := reflect.New(toValue.Type().Elem()).Elem()
+
+	if ok, err := fc.copyValue(fromValue, v); !ok {
+		return err
+	}
+
+	toValue.SetMapIndex(k, v)
+
+	return nil
+}
+
+func copyValue(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	return (&copier{}).copyValue(fromValue, toValue)
+}
+
+func (c *copier) copyValue(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if from := indirectInterface(fromValue); from.IsValid() && from.Kind() != reflect.Interface {
+		allocValue(toValue)
+	}
+
+	if ok, err := c.convert(fromValue, toValue); ok || err != nil {
+		if ok {
+			c.afterCopy(toValue)
+		}
+
+		return ok, err
+	}
+
+	if Recursive {
+		if ok, err := c.copyBoxed(fromValue, toValue); ok || err != nil {
+			if ok {
+				c.afterCopy(toValue)
+			}
+
+			return ok, err
+		}
+	}
+
+	var ok bool
+	var err, required error
+
+	if (c.when != nil || c.stringNormalize != nil || exceedsMaxSliceLen(fromValue)) && canRecurse(fromValue, toValue) && !isOpaque(indirectInterface(fromValue).Type()) {
+		// walk nested values so per-field options reach every field, unless
+		// the destination or a custom service copies the value itself
+
+		ok, err = c.copyCustom(fromValue, toValue)
+
+		if !ok && err == nil {
+			ok, err = true, c.copyValues(fromValue, toValue)
+		}
+	} else {
+		ok, err = c.copyServiceValue(fromValue, toValue)
+
+		if to := indirectValue(toValue); ok && err == nil && to.Kind() == reflect.Struct && hasRequired(to.Type()) {
+			// a struct assigned whole skips the field by field checks
+
+			required = errors.Join(checkRequired(to, nil)...)
+		} else if !ok && err == nil && canRecurse(fromValue, toValue) && !isOpaque(indirectInterface(fromValue).Type()) {
+			ok, err = true, c.copyValues(fromValue, toValue)
+		}
+	}
+
+	if GobFallback && (!ok || err != nil) {
+		if gobOK, gobErr := copyGob(fromValue, toValue); gobOK {
+			ok, err = true, nil
+		} else if err == nil {
+			err = gobErr
+		}
+	}
+
+	if ok {
+		c.afterCopy(toValue)
+	}
+
+	if required != nil {
+		return ok, required
+	}
+
+	return ok, err
+}
+
+func (c *copier) afterCopy(toValue reflect.Value) {
+	if v := indirectValue(toValue); c.stringNormalize != nil && v.Kind() == reflect.String && v.CanSet() {
+		v.SetString(c.stringNormalize(v.String()))
+	}
+
+	if !c.dryRun {
+		runPostHook(toValue)
+	}
+}
+
+func (c *copier) copyBoxed(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+
+	if !fromValue.IsValid() || toValue.Kind() != reflect.Interface || toValue.NumMethod() != 0 || !toValue.CanSet() {
+		return false, nil
+	}
+
+	var v reflect.Value
+
+	switch {
+	case fromValue.Kind() == reflect.Struct && !isOpaque(fromValue.Type()), fromValue.Kind() == reflect.Map:
+		v = reflect.MakeMap(reflect.TypeOf(map[string]any{}))
+	case isList(fromValue.Kind()) && !isBytes(fromValue.Type()):
+		v = reflect.New(reflect.TypeOf([]any{})).Elem()
+	default:
+		return false, nil
+	}
+
+	if err := c.copyValues(fromValue, v); err != nil {
+		return false, err
+	}
+
+	toValue.Set(v)
+
+	return true, nil
+}
+
+func (c *copier) matchField(fromField reflect.StructField, fromTag fieldTag, toType reflect.Type) (reflect.StructField, bool) {
+	if name, ok := c.mapping[fromField.Name]; ok {
+		return fieldByName(toType, name)
+	}
+
+	toField, ok := fieldByName(toType, fromTag.name)
+
+	if !ok && TagToTag {
+		if jsonTag := parseTag(fromField, "json"); !jsonTag.ignore && fromField.Tag.Get("json") != "" {
+			toField, ok = tagIndex(toType, "json")[jsonTag.name]
+			ok = ok && !parseFieldTag(toField).ignore
+		}
+	}
+
+	if !ok && fromTag.name != fromField.Name {
+		toField, ok = fieldByName(toType, fromField.Name)
+	}
+
+	if !ok && c.rename != nil {
+		for i := 0; i < toType.NumField(); i++ {
+			if parseFieldTag(toType.Field(i)).ignore {
+				continue
+			}
+
+			if name := c.rename(toType.Field(i).Name); name == fromField.Name || name == fromTag.name {
+				return toType.Field(i), true
+			}
+		}
+	}
+
+	return toField, ok
+}
+
+func (c *copier) copyField(fromValue reflect.Value, toValue reflect.Value, fromTag fieldTag, toTag fieldTag) (bool, error) {
+	sep, ok := toTag.option("split")
+
+	if !ok {
+		sep, ok = fromTag.option("split")
+	}
+
+	if ok {
+		if ok, err := copySplit(fromValue, toValue, sep); ok || err != nil {
+			return ok, err
+		}
+	}
+
+	scale, ok := toTag.option("scale")
+
+	if !ok {
+		scale, ok = fromTag.option("scale")
+	}
+
+	if ok {
+		if ok, err := copyScale(fromValue, toValue, scale); ok || err != nil {
+			return ok, err
+		}
+	}
+
+	return c.copyValue(fromValue, toValue)
+}
+
+func (c *copier) zeroUnmatched(toValue reflect.Value, matched map[string]bool) {
+	for i := 0; i < toValue.NumField(); i++ {
+		toField := toValue.Type().Field(i)
+
+		if matched[toField.Name] || parseFieldTag(toField).ignore || c.only != nil && !c.only[toField.Name] || !toValue.Field(i).CanSet() {
+			continue
+		}
+
+		if embedded := indirectValue(toValue.Field(i)); toField.Anonymous && embedded.Kind() == reflect.Struct {
+			// keep what was copied into the embedded struct through its
+			// promoted fields and clear the rest of it
+
+			if promoted := promotedMatches(toValue.Type(), i, matched); len(promoted) > 0 {
+				c.zeroUnmatched(embedded, promoted)
+
+				continue
+			}
+		}
+
+		toValue.Field(i).Set(reflect.Zero(toField.Type))
+	}
+}
+
+func promotedMatches(structType reflect.Type, i int, matched map[string]bool) map[string]bool {
+	promoted := map[string]bool{}
+
+	for _, field := range visibleFields(structType) {
+		if len(field.Index) > 1 && field.Index[0] == i && matched[field.Name] {
+			promoted[field.Name] = true
+		}
+	}
+
+	return promoted
+}
+
+func exceedsMaxSliceLen(fromValue reflect.Value) bool {
+	fromValue = indirectInterface(fromValue)
+
+	return MaxSliceLen > 0 && fromValue.Kind() == reflect.Slice && fromValue.Len() > MaxSliceLen
+}
+
+func checkRequired(toValue reflect.Value, matched map[string]bool) []error {
+	var errs []error
+
+	for i := 0; i < toValue.NumField(); i++ {
+		toField := toValue.Type().Field(i)
+
+		if parseFieldTag(toField).hasOption("required") && isZero(toValue.Field(i)) {
+			errs = append(errs, &FieldError{Field: toField.Name, Err: ErrRequired})
+
+			continue
+		}
+
+		// nested structs that were copied have been checked by their own copy
+
+		if toField.IsExported() && toField.Type.Kind() == reflect.Struct && !matched[toField.Name] {
+			for _, err := range checkRequired(toValue.Field(i), nil) {
+				errs = append(errs, &FieldError{Field: toField.Name, Err: err})
+			}
+		}
+	}
+
+	return errs
+}
+
+func hasRequired(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if parseFieldTag(field).hasOption("required") || field.Type.Kind() == reflect.Struct && hasRequired(field.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkImplements(fromValue reflect.Value, toType reflect.Type) error {
+	fromValue = indirectInterface(fromValue)
+
+	if toType.Kind() != reflect.Interface || !fromValue.IsValid() || fromValue.Type().Implements(toType) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s does not implement %s", ErrNotImplemented, fromValue.Type(), toType)
+}
+
+func fillEmpty(toValue reflect.Value) {
+	for i := 0; i < toValue.NumField(); i++ {
+		v := toValue.Field(i)
+
+		if !v.CanSet() {
+			continue
+		}
+
+		switch {
+		case NilSliceAsEmpty && v.Kind() == reflect.Slice && v.IsNil():
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		case NilMapAsEmpty && v.Kind() == reflect.Map && v.IsNil():
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+	}
+}
+
+func canRecurse(fromValue reflect.Value, toValue reflect.Value) bool {
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !toValue.IsValid() || !toValue.CanSet() {
+		return false
+	}
+
+	switch fromValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		return isList(toValue.Kind()) || isSet(toValue.Type())
+	case reflect.Struct, reflect.Map:
+		return toValue.Kind() == reflect.Struct || toValue.Kind() == reflect.Map
+	}
+
+	return false
+}
+
+func isSet(reflectType reflect.Type) bool {
+	return reflectType.Kind() == reflect.Map && reflectType.Elem().Kind() == reflect.Struct && reflectType.Elem().NumField() == 0
+}
+
+func isOpaque(reflectType reflect.Type) bool {
+	if reflectType.Kind() != reflect.Struct || isOrderedMap(reflectType) {
+		return false
+	}
+
+	for i := 0; i < reflectType.NumField(); i++ {
+		if reflectType.Field(i).IsExported() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func allocValue(reflectValue reflect.Value) {
+	for reflectValue.Kind() == reflect.Pointer {
+		if reflectValue.IsNil() {
+			if !reflectValue.CanSet() {
+				return
+			}
+
+			reflectValue.Set(reflect.New(reflectValue.Type().Elem()))
+		}
+
+		reflectValue = reflectValue.Elem()
+	}
+}
+
+func (c *copier) copyServiceValue(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if !c.dryRun && copyFrom(fromValue, toValue) {
+		return true, nil
+	}
+
+	return serviceValue(fromValue, toValue)
+}
+
+// copyCustom is copyServiceValue without the whole value assignment of
+// DefaultService, which would bypass per-field options.
+func (c *copier) copyCustom(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if !c.dryRun && copyFrom(fromValue, toValue) {
+		return true, nil
+	}
+
+	if _, ok := CopyService.(DefaultService); ok {
+		return copySQL(fromValue, toValue)
+	}
+
+	return serviceValue(fromValue, toValue)
+}
+
+func serviceValue(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if s, ok := CopyService.(ServiceE); ok {
+		return s.CopyValueE(fromValue, toValue)
+	}
+
+	return CopyService.CopyValue(fromValue, toValue), nil
+}
+
+func copyFrom(fromValue reflect.Value, toValue reflect.Value) bool {
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !fromValue.CanInterface() || !toValue.CanSet() {
+		return false
+	}
+
+	if v, ok := toValue.Addr().Interface().(CopyFromer); ok {
+		return v.CopyFrom(fromValue.Interface())
+	}
+
+	return false
+}
+
+var copyFromerType = reflect.TypeOf((*CopyFromer)(nil)).Elem()
+
+var fastStructs sync.Map
+
+// fastCopy copies between values of the same type with a single Set where
+// that yields the same result as the field by field copy.
+func fastCopy(fromValue reflect.Value, toValue reflect.Value) bool {
+	reflectType := fromValue.Type()
+
+	if hasPostHooks() || hasConverters() || NormalizeTimeZone || NilSliceAsEmpty || NilMapAsEmpty || MaxSliceLen > 0 {
+		return false
+	}
+
+	switch reflectType.Kind() {
+	case reflect.Struct:
+		if !fastStruct(reflectType) || hasMethodHooks(reflectType) {
+			return false
+		}
+
+		toValue.Set(fromValue)
+	case reflect.Slice:
+		if hasMethodHooks(reflectType.Elem()) || InPlaceSlice && toValue.Len() > 0 {
+			return false
+		}
+
+		toValue.Set(reflect.AppendSlice(toValue, fromValue))
+	case reflect.Map:
+		if hasMethodHooks(reflectType.Elem()) || hasMethodHooks(reflectType.Key()) {
+			return false
+		}
+
+		if toValue.IsNil() {
+			toValue.Set(reflect.MakeMap(reflectType))
+		}
+
+		kv := fromValue.MapRange()
+
+		for kv.Next() {
+			toValue.SetMapIndex(kv.Key(), kv.Value())
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+type fastStructInfo struct {
+	fast     bool
+	exported bool
+}
+
+func hasMethodHooks(reflectType reflect.Type) bool {
+	pointerType := reflect.PointerTo(reflectType)
+
+	return pointerType.Implements(copyFromerType) || pointerType.Implements(afterCopierType)
+}
+
+func fastStruct(structType reflect.Type) bool {
+	key := tagIndexKey{structType, TagKey}
+
+	v, ok := fastStructs.Load(key)
+
+	if !ok {
+		info := fastStructInfo{fast: true, exported: true}
+
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+
+			if !field.IsExported() {
+				info.exported = false
+			}
+
+			if tag := parseFieldTag(field); tag.ignore || len(tag.options) > 0 || field.Type.Kind() == reflect.Pointer || hasMethodHooks(field.Type) ||
+				field.Type.Kind() == reflect.Struct && hasRequired(field.Type) {
+				info.fast = false
+			}
+		}
+
+		v, _ = fastStructs.LoadOrStore(key, info)
+	}
+
+	info := v.(fastStructInfo)
+
+	return info.fast && (info.exported || AllowUnexported)
+}
+
+func assignInterface(fromValue reflect.Value, toValue reflect.Value) bool {
+	if toValue.Kind() != reflect.Interface || !toValue.CanSet() {
+		return false
+	}
+
+	for v := fromValue; v.IsValid() && v.CanInterface(); v = v.Elem() {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return false
+		}
+
+		if v.Type().Implements(toValue.Type()) {
+			toValue.Set(v)
+
+			return true
+		}
+
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			return false
+		}
+	}
+
+	return false
+}
+
+func copyGob(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !fromValue.CanInterface() || !toValue.CanSet() || !isComposite(fromValue.Kind()) || !isComposite(toValue.Kind()) {
+		return false, nil
+	}
+
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).EncodeValue(fromValue); err != nil {
+		return false, err
+	}
+
+	if err := gob.NewDecoder(&buf).DecodeValue(toValue); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func checkConversion(fromValue reflect.Value, toType reflect.Type) error {
+	v := reflect.New(toType).Elem()
+	overflow := false
+
+	switch {
+	case isInt(fromValue.Kind()) && isInt(toType.Kind()):
+		overflow = v.OverflowInt(fromValue.Int())
+	case isInt(fromValue.Kind()) && isUint(toType.Kind()):
+		overflow = fromValue.Int() < 0 || v.OverflowUint(uint64(fromValue.Int()))
+	case isUint(fromValue.Kind()) && isInt(toType.Kind()):
+		overflow = fromValue.Uint() > math.MaxInt64 || v.OverflowInt(int64(fromValue.Uint()))
+	case isUint(fromValue.Kind()) && isUint(toType.Kind()):
+		overflow = v.OverflowUint(fromValue.Uint())
+	}
+
+	if overflow {
+		return fmt.Errorf("%w: cannot copy %v (%s) into %s", ErrOverflow, fromValue, fromValue.Type(), toType)
+	}
+
+	return nil
+}
+
+func checkFloat(fromValue reflect.Value, toType reflect.Type) error {
+	if fromValue.Kind() != reflect.Float64 || toType.Kind() != reflect.Float32 {
+		return nil
+	}
+
+	f := fromValue.Float()
+	narrowed := float64(float32(f))
+
+	if math.IsInf(narrowed, 0) && !math.IsInf(f, 0) || FloatTolerance > 0 && math.Abs(narrowed-f) > FloatTolerance*math.Abs(f) {
+		return fmt.Errorf("%w: cannot copy %v (%s) into %s", ErrOverflow, fromValue, fromValue.Type(), toType)
+	}
+
+	return nil
+}
+
+var layouts = map[string]string{
+	"Layout":      time.Layout,
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+func getLayout() string {
+	return resolveLayout(DateTimeLayout)
+}
+
+func resolveLayout(layout string) string {
+	if v, ok := layouts[layout]; ok {
+		return v
+	}
+
+	return layout
+}
+
+func getTimeZone() *time.Location {
+	if v, err := time.LoadLocation(TimeZone); err == nil {
+		return v
+	}
+
+	if FallbackTimeZone != nil {
+		return FallbackTimeZone
+	}
+
+	return time.UTC
+}
+
+// CheckTimeZone reports whether TimeZone can be loaded. Copies fall back
+// to FallbackTimeZone when it cannot, so call this at startup to catch a
+// misspelled zone or missing zoneinfo instead of shifting times silently.
+func CheckTimeZone() error {
+	if _, err := time.LoadLocation(TimeZone); err != nil {
+		return fmt.Errorf("%w: %w", ErrTimeZone, err)
+	}
+
+	return nil
+}
+
+func strictTimeZone() error {
+	if !StrictTimeZone {
+		return nil
+	}
+
+	return CheckTimeZone()
+}
+
+func indirectValue(reflectValue reflect.Value) reflect.Value {
+	for reflectValue.Kind() == reflect.Pointer {
+		reflectValue = reflectValue.Elem()
+	}
+
+	return reflectValue
+}
+
+func exposeValue(reflectValue reflect.Value) reflect.Value {
+	if reflectValue.CanSet() || !reflectValue.CanAddr() {
+		return reflectValue
+	}
+
+	return reflect.NewAt(reflectValue.Type(), unsafe.Pointer(reflectValue.UnsafeAddr())).Elem()
+}
+
+func (c *copier) skipMerge(fromValue reflect.Value, toValue reflect.Value) bool {
+	return c.onlyNonZero && isZero(fromValue) || c.keepExisting && !isZero(toValue)
+}
+
+func isZero(reflectValue reflect.Value) bool {
+	for reflectValue.Kind() == reflect.Interface && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+
+	if !reflectValue.IsValid() {
+		return true
+	}
+
+	if check, ok := zeroCheck(reflectValue.Type()); ok {
+		return check(reflectValue)
+	}
+
+	if reflectValue.IsZero() {
+		return true
+	}
+
+	if sentinel, ok := SentinelZero[reflectValue.Type()]; ok && reflectValue.CanInterface() {
+		return reflect.DeepEqual(reflectValue.Interface(), sentinel)
+	}
+
+	return false
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+
+	return false
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isComposite(kind reflect.Kind) bool {
+	return kind == reflect.Struct || isCollection(kind)
+}
+
+func isList(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+func isCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
+
+func indirectInterface(reflectValue reflect.Value) reflect.Value {
+	for reflectValue.Kind() == reflect.Pointer || reflectValue.Kind() == reflect.Interface && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+
+	return reflectValue
+}
+
+func indirectType(reflectType reflect.Type) reflect.Type {
 	for reflectType.Kind() == reflect.Pointer {
 		reflectType = reflectType.Elem()
 	}
 
 	return reflectType
 }
+
+func (c *copier) copyExtra(extras reflect.Value, key string, fromValue reflect.Value) error {
+	if extras.IsNil() {
+		extras.Set(reflect.MakeMap(extras.Type()))
+	}
+
+	k := reflect.New(extras.Type().Key()).Elem()
+	k.SetString(key)
+
+	v := reflect.New(extras.Type().Elem()).Elem()
+
+	ok, err := c.at(key).copyValue(fromValue, v)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if from := indirectInterface(fromValue); from.IsValid() && from.Kind() != reflect.Interface {
+			return fmt.Errorf("%w: %s into %s", ErrUnconvertible, from.Type(), v.Type())
+		}
+
+		return nil
+	}
+
+	extras.SetMapIndex(k, v)
+
+	return nil
+}
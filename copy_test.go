@@ -0,0 +1,1815 @@
+package copy
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setVar sets a package variable for the duration of a test.
+func setVar[T any](t *testing.T, p *T, v T) {
+	t.Helper()
+
+	old := *p
+	*p = v
+
+	t.Cleanup(func() {
+		*p = old
+	})
+}
+
+func TestCopyNilAndEmptySlices(t *testing.T) {
+	type S struct {
+		Items []int
+	}
+
+	type D struct {
+		Items []string
+	}
+
+	tests := []struct {
+		name    string
+		from    S
+		wantNil bool
+	}{
+		{"nil source", S{Items: nil}, true},
+		{"empty source", S{Items: []int{}}, false},
+		{"non-empty source", S{Items: []int{1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if (d.Items == nil) != tt.wantNil {
+				t.Fatalf("Items = %#v, want nil %v", d.Items, tt.wantNil)
+			}
+
+			if len(d.Items) != len(tt.from.Items) {
+				t.Fatalf("len(Items) = %d, want %d", len(d.Items), len(tt.from.Items))
+			}
+		})
+	}
+}
+
+func TestCheckedConversions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    any
+		to      any
+		checked bool
+		wantErr bool
+	}{
+		{"negative into uint unchecked", int64(-1), new(uint), false, false},
+		{"negative into uint checked", int64(-1), new(uint), true, true},
+		{"large uint into int64 checked", uint64(math.MaxUint64), new(int64), true, true},
+		{"large int into int8 checked", 300, new(int8), true, true},
+		{"in range checked", int64(5), new(uint8), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &CheckedConversions, tt.checked)
+
+			err := CopyE(tt.from, tt.to)
+
+			if tt.wantErr != errors.Is(err, ErrOverflow) {
+				t.Fatalf("err = %v, want ErrOverflow %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var u uint
+
+	Copy(-1, &u)
+
+	if u != math.MaxUint {
+		t.Fatalf("unchecked copy = %d, want wrapped value", u)
+	}
+}
+
+type animal interface {
+	Sound() string
+}
+
+type dog struct {
+	Name string
+}
+
+func (d *dog) Sound() string {
+	return d.Name + ": woof"
+}
+
+func TestCopyIntoInterfaceSlice(t *testing.T) {
+	var animals []animal
+
+	if err := CopyE([]*dog{{Name: "rex"}, {Name: "fido"}}, &animals); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(animals) != 2 || animals[0].Sound() != "rex: woof" || animals[1].Sound() != "fido: woof" {
+		t.Fatalf("got %v", animals)
+	}
+}
+
+func TestNumberStringToggles(t *testing.T) {
+	type S struct {
+		N string
+		S int
+	}
+
+	type D struct {
+		N int
+		S string
+	}
+
+	tests := []struct {
+		name           string
+		stringToNumber bool
+		numberToString bool
+		want           D
+	}{
+		{"both enabled", true, true, D{N: 42, S: "7"}},
+		{"string to number disabled", false, true, D{S: "7"}},
+		{"number to string disabled", true, false, D{N: 42}},
+		{"both disabled", false, false, D{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &StringToNumber, tt.stringToNumber)
+			setVar(t, &NumberToString, tt.numberToString)
+
+			var d D
+
+			Copy(S{N: "42", S: 7}, &d)
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+type customDest struct {
+	V     string
+	Calls int
+}
+
+func (d *customDest) CopyFrom(from any) bool {
+	d.V = "custom"
+	d.Calls++
+
+	return true
+}
+
+func TestCopyFrom(t *testing.T) {
+	type inner struct {
+		V string
+	}
+
+	t.Run("top level", func(t *testing.T) {
+		var d customDest
+
+		Copy(inner{V: "x"}, &d)
+
+		if d.V != "custom" || d.Calls != 1 {
+			t.Fatalf("got %+v", d)
+		}
+	})
+
+	t.Run("nested field", func(t *testing.T) {
+		type S struct {
+			In inner
+		}
+
+		type D struct {
+			In customDest
+		}
+
+		var d D
+
+		Copy(S{In: inner{V: "x"}}, &d)
+
+		if d.In.V != "custom" || d.In.Calls != 1 {
+			t.Fatalf("got %+v", d)
+		}
+	})
+}
+
+func TestNamedLayouts(t *testing.T) {
+	setVar(t, &TimeZone, "UTC")
+
+	tm := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"RFC3339", "2024-03-04T05:06:07Z"},
+		{"DateOnly", "2024-03-04"},
+		{"Kitchen", "5:06AM"},
+		{"2006/01/02", "2024/03/04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			setVar(t, &DateTimeLayout, tt.layout)
+
+			var s string
+
+			Copy(struct{ T time.Time }{tm}, &struct{ T *string }{&s})
+
+			if s != tt.want {
+				t.Fatalf("formatted %q, want %q", s, tt.want)
+			}
+
+			var d struct{ T time.Time }
+
+			Copy(struct{ T string }{tt.want}, &d)
+
+			if d.T.Format(resolveLayout(tt.layout)) != tt.want {
+				t.Fatalf("parsed %v from %q", d.T, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowUnexported(t *testing.T) {
+	type fixture struct {
+		Name  string
+		count int
+		tags  []string
+	}
+
+	type twin struct {
+		Name  string
+		count int
+		tags  []string
+	}
+
+	from := fixture{Name: "a", count: 3, tags: []string{"x"}}
+
+	tests := []struct {
+		name  string
+		allow bool
+		want  fixture
+	}{
+		{"disabled", false, fixture{Name: "a"}},
+		{"enabled", true, from},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &AllowUnexported, tt.allow)
+
+			var d fixture
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Name != tt.want.Name || d.count != tt.want.count || len(d.tags) != len(tt.want.tags) {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+
+			var tw twin
+
+			if err := CopyE(from, &tw); err != nil {
+				t.Fatal(err)
+			}
+
+			if tw.Name != tt.want.Name || tw.count != tt.want.count || len(tw.tags) != len(tt.want.tags) {
+				t.Fatalf("got %+v, want %+v", tw, tt.want)
+			}
+		})
+	}
+}
+
+func TestScalarIntoSlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		broadcast bool
+		existing  []int
+		want      []int
+	}{
+		{"nil destination", false, nil, []int{7}},
+		{"replaces existing", false, []int{1, 2, 3}, []int{7}},
+		{"broadcast into existing", true, []int{1, 2, 3}, []int{7, 7, 7}},
+		{"broadcast into empty", true, nil, []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &ScalarBroadcast, tt.broadcast)
+
+			d := struct{ V []int }{tt.existing}
+
+			Copy(struct{ V string }{"7"}, &d)
+
+			if !reflect.DeepEqual(d.V, tt.want) {
+				t.Fatalf("got %v, want %v", d.V, tt.want)
+			}
+		})
+	}
+}
+
+type benchRecord struct {
+	ID     int
+	Name   string
+	Score  float64
+	Tags   []string
+	Labels map[string]string
+}
+
+func newBenchRecord() benchRecord {
+	return benchRecord{ID: 1, Name: "ann", Score: 0.5, Tags: []string{"a", "b"}, Labels: map[string]string{"k": "v"}}
+}
+
+// fieldwise forces the field by field copy by setting a per-field option.
+func fieldwise() Option {
+	return When(func(string, reflect.Value) bool {
+		return true
+	})
+}
+
+func TestSameTypeFastPath(t *testing.T) {
+	from := newBenchRecord()
+
+	var fast, slow benchRecord
+
+	Copy(from, &fast)
+	Copy(from, &slow, fieldwise())
+
+	if !reflect.DeepEqual(fast, from) || !reflect.DeepEqual(slow, from) {
+		t.Fatalf("fast %+v, fieldwise %+v, want %+v", fast, slow, from)
+	}
+
+	records := []benchRecord{from, from}
+
+	var list []benchRecord
+
+	Copy(records, &list)
+
+	if !reflect.DeepEqual(list, records) {
+		t.Fatalf("got %+v", list)
+	}
+}
+
+func BenchmarkCopySameType(b *testing.B) {
+	from := newBenchRecord()
+
+	b.Run("fast", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var to benchRecord
+
+			Copy(from, &to)
+		}
+	})
+
+	b.Run("fieldwise", func(b *testing.B) {
+		opt := fieldwise()
+
+		for i := 0; i < b.N; i++ {
+			var to benchRecord
+
+			Copy(from, &to, opt)
+		}
+	})
+}
+
+type sealed struct {
+	secret []byte
+}
+
+func (s sealed) GobEncode() ([]byte, error) {
+	return s.secret, nil
+}
+
+type unsealed struct {
+	secret []byte
+	size   int
+}
+
+func (u *unsealed) GobDecode(b []byte) error {
+	u.secret = b
+	u.size = len(b)
+
+	return nil
+}
+
+func TestGobFallback(t *testing.T) {
+	setVar(t, &GobFallback, true)
+
+	t.Run("reflective copy first", func(t *testing.T) {
+		type A struct {
+			N int
+		}
+
+		type B struct {
+			N string
+		}
+
+		var d struct{ In B }
+
+		if err := CopyE(struct{ In A }{A{N: 1}}, &d); err != nil {
+			t.Fatal(err)
+		}
+
+		if d.In.N != "1" {
+			t.Fatalf("got %+v", d)
+		}
+	})
+
+	t.Run("opaque structs", func(t *testing.T) {
+		from := struct{ In sealed }{sealed{secret: []byte("abc")}}
+
+		var d struct{ In unsealed }
+
+		if err := CopyE(from, &d); err != nil {
+			t.Fatal(err)
+		}
+
+		from.In.secret[0] = 'x'
+
+		if string(d.In.secret) != "abc" || d.In.size != 3 {
+			t.Fatalf("got %q", d.In.secret)
+		}
+
+		setVar(t, &GobFallback, false)
+
+		d = struct{ In unsealed }{}
+
+		Copy(from, &d)
+
+		if d.In.secret != nil {
+			t.Fatalf("copied %q without GobFallback", d.In.secret)
+		}
+	})
+}
+
+func TestCopyValues(t *testing.T) {
+	type S struct {
+		ID   int
+		Name string
+	}
+
+	type D struct {
+		ID   string
+		Name string
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		var d D
+
+		if err := CopyValues(reflect.ValueOf(S{ID: 1, Name: "a"}), reflect.ValueOf(&d)); err != nil {
+			t.Fatal(err)
+		}
+
+		if d != (D{ID: "1", Name: "a"}) {
+			t.Fatalf("got %+v", d)
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		var d []D
+
+		if err := CopyValues(reflect.ValueOf([]S{{ID: 1}, {ID: 2}}), reflect.ValueOf(&d).Elem()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(d, []D{{ID: "1"}, {ID: "2"}}) {
+			t.Fatalf("got %+v", d)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		d := map[string]any{}
+
+		if err := CopyValues(reflect.ValueOf(S{ID: 1, Name: "a"}), reflect.ValueOf(d)); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(d, map[string]any{"ID": 1, "Name": "a"}) {
+			t.Fatalf("got %v", d)
+		}
+	})
+
+	if err := CopyValues(reflect.ValueOf(S{}), reflect.ValueOf(D{})); err != ErrInvalidDestination {
+		t.Fatalf("err = %v, want ErrInvalidDestination", err)
+	}
+}
+
+func TestInPlaceSlice(t *testing.T) {
+	type S struct {
+		Name string
+	}
+
+	type D struct {
+		Name  string
+		State string
+	}
+
+	from := []S{{Name: "a"}, {Name: "b"}}
+
+	tests := []struct {
+		name    string
+		inPlace bool
+		wantLen int
+		want    D
+	}{
+		{"default appends", false, 3, D{State: "s"}},
+		{"in place", true, 2, D{Name: "a", State: "s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &InPlaceSlice, tt.inPlace)
+
+			first := &D{State: "s"}
+			d := []*D{first}
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(d) != tt.wantLen || d[0] != first || *first != tt.want || d[len(d)-1].Name != "b" {
+				t.Fatalf("got %d elements, first %+v", len(d), *first)
+			}
+		})
+	}
+}
+
+func TestCopyIntoAnonymousStruct(t *testing.T) {
+	type Model struct {
+		UserID   int
+		FullName string
+		Password string
+	}
+
+	var out struct {
+		ID       string `copy:"UserID"`
+		Name     string `copy:"FullName"`
+		Password string `copy:"-"`
+	}
+
+	if err := CopyE(&Model{UserID: 7, FullName: "n", Password: "p"}, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.ID != "7" || out.Name != "n" || out.Password != "" {
+		t.Fatalf("got %+v", out)
+	}
+
+	var list []struct {
+		Name string `copy:"FullName"`
+	}
+
+	if err := CopyE([]Model{{FullName: "a"}}, &list); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 1 || list[0].Name != "a" {
+		t.Fatalf("got %+v", list)
+	}
+
+	var nested struct {
+		Inner struct {
+			X string `copy:"FullName"`
+		}
+	}
+
+	if err := CopyE(struct{ Inner Model }{Model{FullName: "z"}}, &nested); err != nil {
+		t.Fatal(err)
+	}
+
+	if nested.Inner.X != "z" {
+		t.Fatalf("got %+v", nested)
+	}
+}
+
+func TestPointerAndValueFields(t *testing.T) {
+	type PV struct {
+		P *int
+		Q **int
+	}
+
+	type VV struct {
+		P int
+		Q int
+	}
+
+	type PP struct {
+		P *int64
+		Q *int
+	}
+
+	n := 5
+	pn := &n
+
+	t.Run("pointer into value", func(t *testing.T) {
+		var v VV
+
+		Copy(PV{P: &n, Q: &pn}, &v)
+
+		if v != (VV{P: 5, Q: 5}) {
+			t.Fatalf("got %+v", v)
+		}
+	})
+
+	t.Run("nil pointer into value", func(t *testing.T) {
+		v := VV{P: 1, Q: 2}
+
+		Copy(PV{}, &v)
+
+		if v != (VV{P: 1, Q: 2}) {
+			t.Fatalf("got %+v", v)
+		}
+	})
+
+	t.Run("value into pointer", func(t *testing.T) {
+		var p PV
+
+		Copy(VV{P: 3, Q: 4}, &p)
+
+		if p.P == nil || *p.P != 3 || p.Q == nil || **p.Q != 4 {
+			t.Fatalf("got %+v", p)
+		}
+	})
+
+	t.Run("pointer into pointer", func(t *testing.T) {
+		var p PP
+
+		Copy(PV{}, &p)
+
+		if p.P != nil || p.Q != nil {
+			t.Fatalf("nil source allocated %+v", p)
+		}
+
+		Copy(PV{P: &n}, &p)
+
+		if p.P == nil || *p.P != 5 || p.Q != nil {
+			t.Fatalf("got %+v", p)
+		}
+	})
+
+	t.Run("map values", func(t *testing.T) {
+		var p PP
+
+		Copy(map[string]any{"P": nil, "Q": 3}, &p)
+
+		if p.P != nil || p.Q == nil || *p.Q != 3 {
+			t.Fatalf("got %+v", p)
+		}
+	})
+}
+
+func TestStrictSettable(t *testing.T) {
+	type S struct {
+		Name string
+		Age  int
+	}
+
+	type D struct {
+		name string `copy:"Name"`
+		age  int    `copy:"Age"`
+	}
+
+	tests := []struct {
+		name   string
+		strict bool
+		from   any
+	}{
+		{"struct lenient", false, S{Name: "n", Age: 1}},
+		{"struct strict", true, S{Name: "n", Age: 1}},
+		{"map lenient", false, map[string]any{"Name": "x"}},
+		{"map strict", true, map[string]any{"Name": "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &StrictSettable, tt.strict)
+
+			var d D
+
+			err := CopyE(tt.from, &d)
+
+			if errors.Is(err, ErrNotSettable) != tt.strict {
+				t.Fatalf("err = %v, want ErrNotSettable %v", err, tt.strict)
+			}
+
+			if d != (D{}) {
+				t.Fatalf("got %+v", d)
+			}
+		})
+	}
+}
+
+func TestRuneStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		runes bool
+		from  any
+		to    any
+		want  any
+	}{
+		{"rune to string default", false, 'A', new(string), "65"},
+		{"rune to string", true, 'é', new(string), "é"},
+		{"string to rune", true, "é", new(rune), 'é'},
+		{"numeric string to rune", true, "42", new(rune), rune(42)},
+		{"string to byte", true, "x", new(byte), byte('x')},
+		{"byte to string", true, byte('x'), new(string), "x"},
+		{"int64 stays numeric", true, int64(65), new(string), "65"},
+		{"string to int64 stays numeric", true, "7", new(int64), int64(7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &RuneStrings, tt.runes)
+
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := reflect.ValueOf(tt.to).Elem().Interface(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type scannedName struct {
+	Value string
+}
+
+func (n *scannedName) Scan(src any) error {
+	n.Value = "scanned " + src.(string)
+
+	return nil
+}
+
+type stubService struct{}
+
+func (stubService) CopyValue(fromValue reflect.Value, toValue reflect.Value) bool {
+	if toValue.Type() != reflect.TypeOf(struct{ Upper string }{}) {
+		return DefaultService{}.CopyValue(fromValue, toValue)
+	}
+
+	toValue.Field(0).SetString("from service")
+
+	return true
+}
+
+func TestPerFieldOptionsKeepCustomCopies(t *testing.T) {
+	type In struct {
+		V string
+	}
+
+	type S struct {
+		Custom In
+		Name   sql.NullString
+		Plain  In
+	}
+
+	type D struct {
+		Custom customDest
+		Name   scannedName
+		Plain  struct{ V string }
+	}
+
+	from := S{Custom: In{V: "x"}, Name: sql.NullString{String: "ann", Valid: true}, Plain: In{V: " p "}}
+
+	tests := []struct {
+		name string
+		opt  Option
+		want string
+	}{
+		{"When", fieldwise(), " p "},
+		{"StringNormalize", StringNormalize(strings.TrimSpace), "p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			if err := CopyE(from, &d, tt.opt); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Custom.V != "custom" || d.Name.Value != "scanned ann" || d.Plain.V != tt.want {
+				t.Fatalf("got %+v", d)
+			}
+		})
+	}
+
+	t.Run("custom service", func(t *testing.T) {
+		setVar[Service](t, &CopyService, stubService{})
+
+		var d struct {
+			Upper struct{ Upper string }
+		}
+
+		if err := CopyE(struct{ Upper In }{In{V: "u"}}, &d, fieldwise()); err != nil {
+			t.Fatal(err)
+		}
+
+		if d.Upper.Upper != "from service" {
+			t.Fatalf("got %+v", d)
+		}
+	})
+}
+
+func TestBoolStrings(t *testing.T) {
+	tests := []struct {
+		name    string
+		strings map[bool]string
+		from    bool
+		want    string
+	}{
+		{"default true", nil, true, "true"},
+		{"default false", nil, false, "false"},
+		{"custom true", map[bool]string{true: "Y", false: "N"}, true, "Y"},
+		{"custom false", map[bool]string{true: "Y", false: "N"}, false, "N"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &BoolStrings, tt.strings)
+
+			var s string
+
+			if err := CopyE(tt.from, &s); err != nil {
+				t.Fatal(err)
+			}
+
+			if s != tt.want {
+				t.Fatalf("got %q, want %q", s, tt.want)
+			}
+
+			b := !tt.from
+
+			if err := CopyE(s, &b); err != nil {
+				t.Fatal(err)
+			}
+
+			if b != tt.from {
+				t.Fatalf("parsed %q as %v", s, b)
+			}
+		})
+	}
+}
+
+type (
+	celsius  float64
+	userID   int64
+	userName string
+)
+
+func TestNamedDestinationKinds(t *testing.T) {
+	type D struct {
+		Temp celsius
+		ID   userID
+		N    userName
+		N2   userName
+	}
+
+	tests := []struct {
+		name string
+		from any
+		want D
+	}{
+		{"from strings and numbers", map[string]any{"Temp": "21.5", "ID": "42", "N": 7, "N2": 1.5}, D{Temp: 21.5, ID: 42, N: "7", N2: "1.5"}},
+		{"from struct fields", struct {
+			Temp int
+			ID   string
+			N    float64
+			N2   bool
+		}{3, "9", 2.5, true}, D{Temp: 3, ID: 9, N: "2.5", N2: "true"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+
+	var c celsius
+
+	Copy(userID(5), &c)
+
+	if c != 5 {
+		t.Fatalf("named to named = %v", c)
+	}
+}
+
+func TestSliceIntoSet(t *testing.T) {
+	var set map[string]struct{}
+
+	if err := CopyE([]string{"a", "b", "a"}, &set); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(set, map[string]struct{}{"a": {}, "b": {}}) {
+		t.Fatalf("got %v", set)
+	}
+
+	var d struct{ Tags map[int]struct{} }
+
+	if err := CopyE(struct{ Tags []string }{[]string{"1", "2", "2"}}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(d.Tags, map[int]struct{}{1: {}, 2: {}}) {
+		t.Fatalf("got %v", d.Tags)
+	}
+}
+
+type color int
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+func TestUseStringer(t *testing.T) {
+	tests := []struct {
+		name     string
+		stringer bool
+		want     string
+	}{
+		{"disabled", false, "1"},
+		{"enabled", true, "green"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &UseStringer, tt.stringer)
+
+			var d struct{ C string }
+
+			if err := CopyE(struct{ C color }{1}, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.C != tt.want {
+				t.Fatalf("got %q, want %q", d.C, tt.want)
+			}
+		})
+	}
+}
+
+func TestArraysOfStructs(t *testing.T) {
+	type S struct {
+		Name string `copy:"Title"`
+		N    string
+	}
+
+	type D struct {
+		Title string
+		N     int
+	}
+
+	from := [2]S{{Name: "a", N: "1"}, {Name: "b", N: "2"}}
+
+	var arr [2]D
+
+	if err := CopyE(from, &arr); err != nil {
+		t.Fatal(err)
+	}
+
+	if arr != [2]D{{Title: "a", N: 1}, {Title: "b", N: 2}} {
+		t.Fatalf("array = %+v", arr)
+	}
+
+	var list []D
+
+	if err := CopyE(from, &list); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(list, []D{{Title: "a", N: 1}, {Title: "b", N: 2}}) {
+		t.Fatalf("slice = %+v", list)
+	}
+
+	var short struct{ L [1]D }
+
+	err := CopyE(struct{ L []S }{[]S{{Name: "x", N: "3"}, {Name: "y", N: "4"}}}, &short)
+
+	if !errors.Is(err, ErrLength) || short.L[0] != (D{Title: "x", N: 3}) {
+		t.Fatalf("got %+v, err = %v", short, err)
+	}
+}
+
+func TestZeroUnmatched(t *testing.T) {
+	type D struct {
+		A, B string
+		Keep string `copy:"-"`
+	}
+
+	tests := []struct {
+		name  string
+		reset bool
+		from  any
+		want  D
+	}{
+		{"struct source", true, struct{ A string }{"x"}, D{A: "x", Keep: "k"}},
+		{"map source", true, map[string]any{"B": "y"}, D{B: "y", Keep: "k"}},
+		{"disabled", false, struct{ A string }{"x"}, D{A: "x", B: "b", Keep: "k"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &ZeroUnmatched, tt.reset)
+
+			d := D{A: "a", B: "b", Keep: "k"}
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroUnmatchedEmbedded(t *testing.T) {
+	setVar(t, &ZeroUnmatched, true)
+
+	type Base struct {
+		X, Y int
+	}
+
+	type D struct {
+		Base
+		Name string
+	}
+
+	type P struct {
+		*Base
+		Name string
+	}
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"promoted field", struct{ X int }{5}, &D{Base{1, 2}, "n"}, &D{Base: Base{X: 5}}},
+		{"whole embedded struct", struct{ Base Base }{Base{X: 3}}, &D{Base{1, 2}, "n"}, &D{Base: Base{X: 3}}},
+		{"unmatched embedded struct", struct{ Name string }{"m"}, &D{Base{1, 2}, "n"}, &D{Name: "m"}},
+		{"map source", map[string]any{"Y": 4}, &D{Base{1, 2}, "n"}, &D{Base: Base{Y: 4}}},
+		{"embedded pointer", struct{ X int }{5}, &P{&Base{1, 2}, "n"}, &P{Base: &Base{X: 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %+v, want %+v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+type box[T any] struct {
+	Value T
+}
+
+type pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+func TestGenericDestinations(t *testing.T) {
+	type text struct {
+		Value string
+	}
+
+	t.Run("box of string", func(t *testing.T) {
+		var b box[string]
+
+		if err := CopyE(struct{ Value int }{5}, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.Value != "5" {
+			t.Fatalf("got %+v", b)
+		}
+	})
+
+	t.Run("nested pair", func(t *testing.T) {
+		var p pair[box[int], box[string]]
+
+		if err := CopyE(struct{ First, Second text }{text{"3"}, text{"n"}}, &p); err != nil {
+			t.Fatal(err)
+		}
+
+		if p.First.Value != 3 || p.Second.Value != "n" {
+			t.Fatalf("got %+v", p)
+		}
+	})
+
+	t.Run("box of any", func(t *testing.T) {
+		var b box[any]
+
+		if err := CopyE(box[int]{4}, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.Value != 4 {
+			t.Fatalf("got %+v", b)
+		}
+	})
+
+	t.Run("box of pointer", func(t *testing.T) {
+		var b box[*int]
+
+		if err := CopyE(box[string]{"9"}, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.Value == nil || *b.Value != 9 {
+			t.Fatalf("got %+v", b)
+		}
+	})
+
+	t.Run("box of box", func(t *testing.T) {
+		var b box[box[int]]
+
+		if err := CopyE(map[string]any{"Value": map[string]any{"Value": "2"}}, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.Value.Value != 2 {
+			t.Fatalf("got %+v", b)
+		}
+	})
+}
+
+func TestValueStructIntoPointerOfOtherType(t *testing.T) {
+	type AddressModel struct {
+		City string
+		Zip  int
+	}
+
+	type AddressDTO struct {
+		City string
+		Zip  string
+	}
+
+	from := struct {
+		Address AddressModel
+		P       *AddressModel
+	}{AddressModel{City: "x", Zip: 1}, &AddressModel{City: "y", Zip: 2}}
+
+	var d struct {
+		Address *AddressDTO
+		P       **AddressDTO
+	}
+
+	if err := CopyE(from, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Address == nil || *d.Address != (AddressDTO{City: "x", Zip: "1"}) {
+		t.Fatalf("Address = %+v", d.Address)
+	}
+
+	if d.P == nil || *d.P == nil || **d.P != (AddressDTO{City: "y", Zip: "2"}) {
+		t.Fatalf("P = %+v", d.P)
+	}
+}
+
+func TestEmbeddedInterfaceDestination(t *testing.T) {
+	type D struct {
+		io.Reader
+		Name string
+	}
+
+	tests := []struct {
+		name       string
+		from       any
+		wantReader bool
+	}{
+		{"concrete reader", struct {
+			Reader *strings.Reader
+			Name   string
+		}{strings.NewReader("x"), "n"}, true},
+		{"incompatible source", struct {
+			Reader int
+			Name   string
+		}{1, "n"}, false},
+		{"nil interface", struct {
+			Reader io.Reader
+			Name   string
+		}{nil, "n"}, false},
+		{"map source", map[string]any{"Reader": strings.NewReader("y"), "Name": "n"}, true},
+		{"same type", D{strings.NewReader("z"), "n"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if (d.Reader != nil) != tt.wantReader || d.Name != "n" {
+				t.Fatalf("got %+v, want reader %v", d, tt.wantReader)
+			}
+		})
+	}
+}
+
+func TestRecursiveBoxing(t *testing.T) {
+	type Inner struct {
+		X int
+	}
+
+	type S struct {
+		Name string
+		In   Inner
+		L    []Inner
+		T    time.Time
+	}
+
+	from := map[string]S{"k": {Name: "n", In: Inner{X: 1}, L: []Inner{{X: 2}}}}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		want      map[string]any
+	}{
+		{"disabled", false, map[string]any{"k": from["k"]}},
+		{"enabled", true, map[string]any{"k": map[string]any{
+			"Name": "n",
+			"In":   map[string]any{"X": 1},
+			"L":    []any{map[string]any{"X": 2}},
+			"T":    time.Time{},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &Recursive, tt.recursive)
+
+			var d map[string]any
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(d, tt.want) {
+				t.Fatalf("got %#v, want %#v", d, tt.want)
+			}
+		})
+	}
+
+	var ints map[string]any
+
+	Copy(map[string]int{"a": 1}, &ints)
+
+	if ints["a"] != 1 {
+		t.Fatalf("got %v", ints)
+	}
+}
+
+func TestNestedCollections(t *testing.T) {
+	type S struct {
+		N string
+	}
+
+	type D struct {
+		N int
+	}
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"slice of maps", []map[string]int{{"a": 1}, {"b": 2}}, new([]map[string]string), []map[string]string{{"a": "1"}, {"b": "2"}}},
+		{"map of slices", map[string][]S{"x": {{"1"}, {"2"}}}, new(map[string][]D), map[string][]D{"x": {{1}, {2}}}},
+		{"slice of slices of maps", [][]map[string][]string{{{"k": {"1", "2"}}}}, new([][]map[string][]int), [][]map[string][]int{{{"k": {1, 2}}}}},
+		{"map of maps of slices", map[string]map[string][]S{"o": {"1": {{"5"}}}}, new(map[string]map[int][]D), map[string]map[int][]D{"o": {1: {{5}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := reflect.ValueOf(tt.to).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterfaceHoldingPointers(t *testing.T) {
+	type A struct {
+		City string
+		Zip  int
+	}
+
+	type B struct {
+		City string
+		Zip  string
+	}
+
+	pa := &A{City: "c", Zip: 1}
+	ppa := &pa
+
+	var ia any = ppa
+
+	var d struct {
+		Addr B
+		P    *B
+		Any  any
+	}
+
+	if err := CopyE(map[string]any{"Addr": &A{City: "x", Zip: 2}, "P": ppa, "Any": &ia}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Addr != (B{City: "x", Zip: "2"}) || d.P == nil || *d.P != (B{City: "c", Zip: "1"}) || d.Any == nil {
+		t.Fatalf("got %+v", d)
+	}
+
+	var field struct{ Addr B }
+
+	if err := CopyE(struct{ Addr any }{&A{City: "y", Zip: 3}}, &field); err != nil {
+		t.Fatal(err)
+	}
+
+	if field.Addr != (B{City: "y", Zip: "3"}) {
+		t.Fatalf("got %+v", field)
+	}
+
+	var top B
+
+	if err := CopyE(any(&A{City: "z", Zip: 4}), &top); err != nil {
+		t.Fatal(err)
+	}
+
+	if top != (B{City: "z", Zip: "4"}) {
+		t.Fatalf("got %+v", top)
+	}
+}
+
+func TestCheckedFloat(t *testing.T) {
+	tests := []struct {
+		name      string
+		checked   bool
+		tolerance float64
+		from      float64
+		wantErr   bool
+	}{
+		{"overflow unchecked", false, 0, 1e40, false},
+		{"overflow checked", true, 0, 1e40, true},
+		{"large but fine", true, 0, 3e38, false},
+		{"precision loss within default", true, 0, 0.1, false},
+		{"precision loss beyond tolerance", true, 1e-9, 0.1, true},
+		{"exact value with tolerance", true, 1e-9, 0.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &CheckedFloat, tt.checked)
+			setVar(t, &FloatTolerance, tt.tolerance)
+
+			var f float32
+
+			err := CopyE(struct{ F float64 }{tt.from}, &struct{ F *float32 }{&f})
+
+			if errors.Is(err, ErrOverflow) != tt.wantErr {
+				t.Fatalf("err = %v, want ErrOverflow %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && f != float32(tt.from) {
+				t.Fatalf("got %v, want %v", f, float32(tt.from))
+			}
+		})
+	}
+
+	var f float32
+
+	Copy(1e40, &f)
+
+	if !math.IsInf(float64(f), 1) {
+		t.Fatalf("unchecked copy = %v, want +Inf", f)
+	}
+}
+
+func TestNilAsEmpty(t *testing.T) {
+	type In struct {
+		L []int
+		M map[string]int
+	}
+
+	type Out struct {
+		L  []string
+		M  map[string]string
+		X  []int
+		In In
+	}
+
+	from := struct {
+		L  []int
+		In struct{ L []int }
+	}{}
+
+	tests := []struct {
+		name  string
+		empty bool
+		want  string
+	}{
+		{"disabled", false, `{"L":null,"M":null,"X":null,"In":{"L":null,"M":null}}`},
+		{"enabled", true, `{"L":[],"M":{},"X":[],"In":{"L":[],"M":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &NilSliceAsEmpty, tt.empty)
+			setVar(t, &NilMapAsEmpty, tt.empty)
+
+			var o Out
+
+			if err := CopyE(from, &o); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(o)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tt.want {
+				t.Fatalf("got %s, want %s", b, tt.want)
+			}
+
+			var same In
+
+			if err := CopyE(In{}, &same); err != nil {
+				t.Fatal(err)
+			}
+
+			if (same.L != nil) != tt.empty || (same.M != nil) != tt.empty {
+				t.Fatalf("same type copy = %#v", same)
+			}
+		})
+	}
+}
+
+func TestMaxSliceLen(t *testing.T) {
+	setVar(t, &MaxSliceLen, 2)
+
+	tests := []struct {
+		name    string
+		strict  bool
+		from    any
+		to      any
+		wantLen int
+		wantErr bool
+	}{
+		{"top level truncated", false, []int{1, 2, 3}, new([]int), 2, false},
+		{"field truncated", false, struct{ L []int }{[]int{1, 2, 3}}, new(struct{ L []string }), 2, false},
+		{"same type field truncated", false, struct{ L []int }{[]int{1, 2, 3}}, new(struct{ L []int }), 2, false},
+		{"field rejected", true, struct{ L []int }{[]int{1, 2, 3}}, new(struct{ L []string }), 0, true},
+		{"within limit", true, []int{1, 2}, new([]int), 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &StrictSliceLen, tt.strict)
+
+			err := CopyE(tt.from, tt.to)
+
+			if errors.Is(err, ErrTooLong) != tt.wantErr {
+				t.Fatalf("err = %v, want ErrTooLong %v", err, tt.wantErr)
+			}
+
+			v := reflect.ValueOf(tt.to).Elem()
+
+			if v.Kind() == reflect.Struct {
+				v = v.Field(0)
+			}
+
+			if v.Len() != tt.wantLen {
+				t.Fatalf("len = %d, want %d", v.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+type (
+	namedA string
+	namedB string
+)
+
+func TestNamedStringTypes(t *testing.T) {
+	var b namedB
+
+	if err := CopyE(namedA("x"), &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if b != "x" {
+		t.Fatalf("got %q", b)
+	}
+
+	var d struct {
+		N namedB
+		P *namedB
+		Q string
+	}
+
+	if err := CopyE(struct {
+		N string
+		P namedA
+		Q namedA
+	}{"n", "p", "q"}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.N != "n" || d.P == nil || *d.P != "p" || d.Q != "q" {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+type (
+	flagA  bool
+	flagB  bool
+	countA int
+	countB int
+	ratioA float32
+	ratioB float64
+)
+
+func TestNamedSameKindTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"bool", flagA(true), new(flagB), flagB(true)},
+		{"int", countA(5), new(countB), countB(5)},
+		{"int into int64", countA(6), new(int64), int64(6)},
+		{"float", ratioA(1.5), new(ratioB), ratioB(1.5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := reflect.ValueOf(tt.to).Elem().Interface(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var d struct {
+		B flagB
+		N *countB
+		R ratioB
+	}
+
+	if err := CopyE(struct {
+		B flagA
+		N countA
+		R ratioA
+	}{true, 7, 2.5}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if !d.B || d.N == nil || *d.N != 7 || d.R != 2.5 {
+		t.Fatalf("fields = %+v", d)
+	}
+}
+
+func TestEmptyAndNilSourceMaps(t *testing.T) {
+	// reflect.DeepEqual tells nil maps from empty ones
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"empty into nil", map[string]int{}, new(map[string]string), &map[string]string{}},
+		{"empty same type into nil", map[string]int{}, new(map[string]int), &map[string]int{}},
+		{"nil keeps destination", map[string]int(nil), &map[string]string{"x": "1"}, &map[string]string{"x": "1"}},
+		{"nil leaves nil", map[string]int(nil), new(map[string]string), new(map[string]string)},
+		{"fields", struct{ M, N map[string]int }{map[string]int{}, nil}, &struct{ M, N map[string]string }{N: map[string]string{"keep": "1"}},
+			&struct{ M, N map[string]string }{map[string]string{}, map[string]string{"keep": "1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %#v, want %#v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenericInstantiations(t *testing.T) {
+	setVar(t, &BytesEncoding, "")
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"int into int64", box[int]{7}, &box[int64]{}, &box[int64]{7}},
+		{"string into bytes", box[string]{"hello"}, &box[[]byte]{}, &box[[]byte]{[]byte("hello")}},
+		{"bytes into string", box[[]byte]{[]byte("hi")}, &box[string]{}, &box[string]{"hi"}},
+		{"nested instantiation", box[box[int]]{box[int]{3}}, &box[box[float64]]{}, &box[box[float64]]{box[float64]{3}}},
+		{"pair of boxes", pair[box[int], int]{box[int]{1}, 2}, &pair[box[uint8], string]{}, &pair[box[uint8], string]{box[uint8]{1}, "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %+v, want %+v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+type pathError struct {
+	path string
+}
+
+func (e *pathError) Error() string {
+	return "path: " + e.path
+}
+
+func TestErrorIntoString(t *testing.T) {
+	var nilPathErr *pathError
+
+	tests := []struct {
+		name string
+		from any
+		want string
+	}{
+		{"error interface", struct{ Err error }{errors.New("boom")}, "boom"},
+		{"nil error clears", struct{ Err error }{}, ""},
+		{"concrete error pointer", struct{ Err *pathError }{&pathError{"x"}}, "path: x"},
+		{"nil error pointer is skipped", struct{ Err *pathError }{nilPathErr}, "old"},
+		{"wrapped", struct{ Err error }{fmt.Errorf("outer: %w", io.EOF)}, "outer: EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := struct{ Err string }{"old"}
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Err != tt.want {
+				t.Fatalf("got %q, want %q", d.Err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapValuesIntoStructs(t *testing.T) {
+	type Addr struct {
+		City string
+		Zip  int
+	}
+
+	type User struct {
+		Name string
+		Addr Addr
+		Tags []string
+	}
+
+	from := map[string]any{
+		"a": map[string]any{"Name": "x", "Addr": map[string]any{"City": "c", "Zip": "12"}, "Tags": []any{"t1"}},
+		"b": map[string]any{"Name": "y"},
+	}
+
+	a := User{Name: "x", Addr: Addr{City: "c", Zip: 12}, Tags: []string{"t1"}}
+	b := User{Name: "y"}
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"struct values", from, new(map[string]User), &map[string]User{"a": a, "b": b}},
+		{"pointer values", from, new(map[string]*User), &map[string]*User{"a": &a, "b": &b}},
+		{"any values stay maps", from, new(map[string]any), &from},
+		{"lists of maps", map[string]any{"g": []any{from["a"], from["b"]}}, new(map[string][]User), &map[string][]User{"g": {a, b}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %+v, want %+v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+type labelled struct {
+	N int
+}
+
+func (l labelled) String() string {
+	return fmt.Sprintf("label %d", l.N)
+}
+
+type unlabelled struct {
+	N int
+}
+
+func TestSkipNonImplementing(t *testing.T) {
+	from := []any{labelled{1}, unlabelled{2}, labelled{3}, nil}
+
+	tests := []struct {
+		name string
+		skip bool
+		want []string
+	}{
+		{"skip", true, []string{"label 1", "label 3"}},
+		{"report", false, []string{"label 1", "label 3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &SkipNonImplementing, tt.skip)
+
+			var to []fmt.Stringer
+
+			err := CopyE(from, &to)
+
+			var fieldErr *FieldError
+
+			switch {
+			case tt.skip && err != nil:
+				t.Fatal(err)
+			case !tt.skip && (!errors.Is(err, ErrNotImplemented) || !errors.As(err, &fieldErr) || fieldErr.Field != "[1]"):
+				t.Fatalf("err = %v, want an ErrNotImplemented FieldError for [1]", err)
+			}
+
+			var got []string
+
+			for _, s := range to {
+				got = append(got, s.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
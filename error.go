@@ -0,0 +1,37 @@
+package copy
+
+import (
+	"reflect"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func copyError(fromValue reflect.Value, toValue reflect.Value) bool {
+	toValue = indirectValue(toValue)
+
+	if toValue.Kind() != reflect.String || !toValue.CanSet() {
+		return false
+	}
+
+	for fromValue.Kind() == reflect.Interface && !fromValue.IsNil() {
+		fromValue = fromValue.Elem()
+	}
+
+	if fromValue.Kind() == reflect.Interface && fromValue.Type().Implements(errorType) {
+		toValue.SetString("")
+
+		return true
+	}
+
+	if !fromValue.IsValid() || !fromValue.CanInterface() || fromValue.Kind() == reflect.String || fromValue.Kind() == reflect.Pointer && fromValue.IsNil() {
+		return false
+	}
+
+	if err, ok := fromValue.Interface().(error); ok {
+		toValue.SetString(err.Error())
+
+		return true
+	}
+
+	return false
+}
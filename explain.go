@@ -0,0 +1,323 @@
+package copy
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type FieldAction struct {
+	Field      string
+	Source     string
+	Conversion string
+	Skip       string
+}
+
+// Explain reports what Copy would do with each destination field without
+// writing to the destination. It matches fields the way Copy does and tries
+// each conversion on a scratch value, but never runs post hooks, CopyFrom
+// methods, getters or setters.
+func Explain(from any, to any, opts ...Option) ([]FieldAction, error) {
+	fromValue := indirectInterface(reflect.ValueOf(from))
+	toValue := reflect.ValueOf(to)
+
+	if !toValue.IsValid() || toValue.Kind() != reflect.Pointer || toValue.IsNil() {
+		return nil, ErrInvalidDestination
+	}
+
+	if !fromValue.IsValid() {
+		return nil, ErrInvalidSource
+	}
+
+	toType := indirectType(toValue.Type())
+	c := newCopier(opts)
+	c.dryRun = true
+
+	// the destination is only read, to check KeepExisting
+
+	if toValue = indirectValue(toValue); !toValue.IsValid() {
+		toValue = reflect.New(toType).Elem()
+	}
+
+	switch {
+	case fromValue.Kind() == reflect.Struct && toType.Kind() == reflect.Struct:
+		return c.explainStruct(fromValue, toValue), nil
+	case fromValue.Kind() == reflect.Map && toType.Kind() == reflect.Struct:
+		return c.explainMap(fromValue, toValue), nil
+	case fromValue.Kind() == reflect.Struct && toType.Kind() == reflect.Map:
+		return c.explainStructMap(fromValue, toValue), nil
+	}
+
+	return nil, fmt.Errorf("%w: cannot explain %s into %s", ErrInvalidSource, fromValue.Type(), toType)
+}
+
+func (c *copier) explainStruct(fromValue reflect.Value, toValue reflect.Value) []FieldAction {
+	fromType, toType := fromValue.Type(), toValue.Type()
+
+	if AllowUnexported && !fromValue.CanAddr() {
+		v := reflect.New(fromType).Elem()
+		v.Set(fromValue)
+		fromValue = v
+	}
+
+	matched := make(map[string]FieldAction)
+
+	matches := c.structMatches(fromType, toType)
+
+	chosen, _ := c.structChoices(matches)
+
+	for _, m := range matches {
+		fromField, toField := m.from, m.to
+
+		if !m.ok {
+			continue
+		}
+
+		if name, ok := chosen[toField.Name]; ok && name != fromField.Name {
+			continue
+		}
+
+		action := FieldAction{Field: toField.Name, Source: fromField.Name}
+
+		fromFieldValue, err := fromValue.FieldByIndexErr(fromField.Index)
+
+		if err == nil && AllowUnexported {
+			fromFieldValue = exposeValue(fromFieldValue)
+		}
+
+		switch {
+		case err != nil:
+			action.Skip = "source value is nil"
+		case !fromFieldValue.CanInterface():
+			action.Skip = "source field is unexported"
+		case !toField.IsExported() && !AllowUnexported:
+			action.Skip = "destination field is unexported"
+		case parseFieldTag(toField).hasOption("omitzero") && isZero(fromFieldValue):
+			action.Skip = "source value is zero"
+		default:
+			toFieldValue, _ := toValue.FieldByIndexErr(toField.Index)
+
+			action.Conversion, action.Skip = c.explainField(toField.Name, fromFieldValue, toFieldValue, toField.Type)
+		}
+
+		matched[toField.Name] = action
+	}
+
+	if GetterPrefix != "" {
+		explainGetters(addressable(fromValue), toType, matched)
+	}
+
+	return explainFields(toType, matched)
+}
+
+func explainGetters(fromValue reflect.Value, toType reflect.Type, matched map[string]FieldAction) {
+	for i := 0; i < toType.NumField(); i++ {
+		toField := toType.Field(i)
+
+		if _, ok := matched[toField.Name]; ok || !toField.IsExported() || parseFieldTag(toField).ignore {
+			continue
+		}
+
+		getter, ok := methodByName(fromValue, GetterPrefix+toField.Name)
+
+		if !ok || getter.Type().NumIn() != 0 || getter.Type().NumOut() != 1 {
+			continue
+		}
+
+		matched[toField.Name] = FieldAction{
+			Field:      toField.Name,
+			Source:     GetterPrefix + toField.Name + "()",
+			Conversion: conversionKind(getter.Type().Out(0), toField.Type),
+		}
+	}
+}
+
+func (c *copier) explainMap(fromValue reflect.Value, toValue reflect.Value) []FieldAction {
+	toType := toValue.Type()
+	matched := make(map[string]FieldAction)
+
+	chosen, _ := c.mapChoices(fromValue, toType)
+
+	kv := fromValue.MapRange()
+
+	for kv.Next() {
+		key := keyString(kv.Key())
+		source := key
+
+		if name, ok := c.mapping[key]; ok {
+			key = name
+		}
+
+		toField, ok := fieldByMapKey(toType, kv.Key(), key)
+
+		if !ok {
+			continue
+		}
+
+		if name, ok := chosen[toField.Name]; ok && name != source {
+			continue
+		}
+
+		action := FieldAction{Field: toField.Name, Source: source}
+
+		switch {
+		case !toField.IsExported():
+			action.Skip = "destination field is unexported"
+		case parseFieldTag(toField).hasOption("omitzero") && isZero(kv.Value()):
+			action.Skip = "source value is zero"
+		default:
+			toFieldValue, _ := toValue.FieldByIndexErr(toField.Index)
+
+			action.Conversion, action.Skip = c.explainField(toField.Name, kv.Value(), toFieldValue, toField.Type)
+		}
+
+		matched[toField.Name] = action
+	}
+
+	return explainFields(toType, matched)
+}
+
+func (c *copier) explainStructMap(fromValue reflect.Value, toValue reflect.Value) []FieldAction {
+	fromType, toType := fromValue.Type(), toValue.Type()
+
+	var actions []FieldAction
+
+	for i := 0; i < fromType.NumField(); i++ {
+		fromField := fromType.Field(i)
+		fromTag := parseFieldTag(fromField)
+
+		name := fromTag.name
+
+		if mapped, ok := c.mapping[fromField.Name]; ok {
+			name = mapped
+		}
+
+		action := FieldAction{Field: name, Source: fromField.Name}
+
+		switch {
+		case fromTag.ignore:
+			action.Skip = "source field is ignored"
+		case !fromField.IsExported():
+			action.Skip = "source field is unexported"
+		case c.only != nil && !c.only[fromField.Name]:
+			action.Skip = "source field is not selected"
+		default:
+			var current reflect.Value
+
+			k := reflect.New(toType.Key()).Elem()
+
+			if ok, _ := c.copyKey(reflect.ValueOf(name), k); ok {
+				current = toValue.MapIndex(k)
+			}
+
+			action.Conversion, action.Skip = c.explainField(name, fromValue.Field(i), current, toType.Elem())
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+func explainFields(toType reflect.Type, matched map[string]FieldAction) []FieldAction {
+	var actions []FieldAction
+	var covered [][]int
+
+	for _, toField := range visibleFields(toType) {
+		if isCovered(toField.Index, covered) {
+			continue
+		}
+
+		if action, ok := matched[toField.Name]; ok {
+			// fields promoted from a matched embedded struct are copied with it
+
+			actions = append(actions, action)
+			covered = append(covered, toField.Index)
+
+			continue
+		}
+
+		if toField.Anonymous && indirectType(toField.Type).Kind() == reflect.Struct {
+			continue
+		}
+
+		action := FieldAction{Field: toField.Name, Skip: "no matching source"}
+
+		if parseFieldTag(toField).ignore {
+			action.Skip = "destination field is ignored"
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// explainField applies the per-field gating of copyValues, then tries the
+// conversion with the field's path so path converters are found.
+func (c *copier) explainField(name string, fromValue reflect.Value, toValue reflect.Value, toType reflect.Type) (string, string) {
+	fc := c.at(name)
+
+	switch {
+	case c.onlyNonZero && isZero(fromValue):
+		return "", "source value is zero"
+	case c.keepExisting && !isZero(toValue):
+		return "", "destination value is kept"
+	case c.when != nil && !c.when(fc.path, fromValue):
+		return "", "skipped by When"
+	}
+
+	return fc.explainValue(fromValue, toType)
+}
+
+func (c *copier) explainValue(fromValue reflect.Value, toType reflect.Type) (string, string) {
+	from := indirectInterface(fromValue)
+
+	if !from.IsValid() {
+		return "", "source value is nil"
+	}
+
+	conversion := conversionKind(from.Type(), toType)
+
+	if conversion == "custom" {
+		// CopyFrom cannot be tried without running it
+
+		return conversion, ""
+	}
+
+	v := reflect.New(toType).Elem()
+
+	ok, err := c.copyValue(fromValue, v)
+
+	if err != nil {
+		return conversion, err.Error()
+	}
+
+	if !ok {
+		return conversion, fmt.Sprintf("cannot copy %s into %s", from.Type(), toType)
+	}
+
+	return conversion, ""
+}
+
+func conversionKind(fromType reflect.Type, toType reflect.Type) string {
+	to := indirectType(toType)
+
+	switch {
+	case reflect.PointerTo(to).Implements(copyFromerType):
+		return "custom"
+	case fromType.AssignableTo(to):
+		return "assign"
+	case to.Kind() == reflect.Interface && fromType.Implements(to):
+		return "assign"
+	case to.Kind() == reflect.String && fromType.Kind() != reflect.String:
+		return "format"
+	case fromType.Kind() == reflect.String && to.Kind() != reflect.String:
+		return "parse"
+	case isComposite(fromType.Kind()) && isComposite(to.Kind()):
+		return "copy"
+	case fromType.ConvertibleTo(to):
+		return "convert"
+	}
+
+	return "none"
+}
@@ -0,0 +1,266 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+
+	type S struct {
+		Base
+		Name  string
+		Age   int
+		Skip  string `copy:"-"`
+		Inner struct{ A int }
+	}
+
+	type D struct {
+		ID     string
+		Name   string
+		Age    string
+		Email  string
+		Skip   string
+		Inner  struct{ A string }
+		Custom customDest
+		hidden int
+	}
+
+	d := D{Name: "keep"}
+
+	got, err := Explain(S{Base: Base{ID: 1}, Name: "n", Age: 3, Skip: "s"}, &d)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FieldAction{
+		{Field: "ID", Source: "ID", Conversion: "format"},
+		{Field: "Name", Source: "Name", Conversion: "assign"},
+		{Field: "Age", Source: "Age", Conversion: "format"},
+		{Field: "Email", Skip: "no matching source"},
+		{Field: "Skip", Skip: "no matching source"},
+		{Field: "Inner", Source: "Inner", Conversion: "copy"},
+		{Field: "Custom", Skip: "no matching source"},
+		{Field: "hidden", Skip: "no matching source"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	if d != (D{Name: "keep"}) {
+		t.Fatalf("destination changed to %+v", d)
+	}
+}
+
+func TestExplainMap(t *testing.T) {
+	type D struct {
+		Name string
+		Age  int
+	}
+
+	tests := []struct {
+		name    string
+		indexed bool
+		from    any
+		want    []FieldAction
+	}{
+		{
+			name: "string keys",
+			from: map[string]any{"Name": "n", "Age": "x"},
+			want: []FieldAction{
+				{Field: "Name", Source: "Name", Conversion: "assign"},
+				{Field: "Age", Source: "Age", Conversion: "parse", Skip: "cannot copy string into int"},
+			},
+		},
+		{
+			name:    "indexed keys",
+			indexed: true,
+			from:    map[int]any{0: "n"},
+			want: []FieldAction{
+				{Field: "Name", Source: "0", Conversion: "assign"},
+				{Field: "Age", Skip: "no matching source"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &IndexedMapKeys, tt.indexed)
+
+			got, err := Explain(tt.from, &D{})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainRunsNoHooks(t *testing.T) {
+	calls := 0
+
+	RegisterPostHook(reflect.TypeOf(email("")), func(reflect.Value) {
+		calls++
+	})
+
+	t.Cleanup(func() {
+		RegisterPostHook(reflect.TypeOf(email("")), nil)
+	})
+
+	type In struct {
+		V string
+	}
+
+	type S struct {
+		Email  string
+		Custom In
+		Nested struct{ Custom In }
+	}
+
+	type D struct {
+		Email  email
+		Custom customDest
+		Nested struct{ Custom customDest }
+	}
+
+	got, err := Explain(S{Email: "a@b"}, &D{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 0 {
+		t.Fatalf("post hook ran %d times", calls)
+	}
+
+	if got[1].Conversion != "custom" || got[1].Skip != "" {
+		t.Fatalf("Custom = %+v", got[1])
+	}
+}
+
+func TestExplainAllowUnexported(t *testing.T) {
+	setVar(t, &AllowUnexported, true)
+
+	type S struct {
+		Name  string
+		count int
+	}
+
+	type D struct {
+		Name  string
+		count string
+	}
+
+	got, err := Explain(S{Name: "n", count: 2}, &D{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FieldAction{
+		{Field: "Name", Source: "Name", Conversion: "assign"},
+		{Field: "count", Source: "count", Conversion: "format"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestExplainPerFieldOptions(t *testing.T) {
+	RegisterPathConverter("Code", func(from reflect.Value, to reflect.Value) (bool, error) {
+		if to.Kind() != reflect.Int {
+			return false, nil
+		}
+
+		to.SetInt(int64(len(from.String())))
+
+		return true, nil
+	})
+
+	t.Cleanup(func() {
+		RegisterPathConverter("Code", nil)
+	})
+
+	type S struct {
+		Name  string
+		Age   int
+		Email string
+		Code  string
+	}
+
+	type D struct {
+		Name  string
+		Age   int
+		Email string
+		Code  int
+	}
+
+	from := S{Name: "n", Email: "e", Code: "abc"}
+
+	rejectName := When(func(path string, _ reflect.Value) bool {
+		return path != "Name"
+	})
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		opt  Option
+		want []FieldAction
+	}{
+		{"When", from, &D{}, rejectName, []FieldAction{
+			{Field: "Name", Source: "Name", Skip: "skipped by When"},
+			{Field: "Age", Source: "Age", Conversion: "assign"},
+			{Field: "Email", Source: "Email", Conversion: "assign"},
+			{Field: "Code", Source: "Code", Conversion: "parse"},
+		}},
+		{"OnlyNonZero", from, &D{}, OnlyNonZero(), []FieldAction{
+			{Field: "Name", Source: "Name", Conversion: "assign"},
+			{Field: "Age", Source: "Age", Skip: "source value is zero"},
+			{Field: "Email", Source: "Email", Conversion: "assign"},
+			{Field: "Code", Source: "Code", Conversion: "parse"},
+		}},
+		{"KeepExisting", from, &D{Email: "kept"}, KeepExisting(), []FieldAction{
+			{Field: "Name", Source: "Name", Conversion: "assign"},
+			{Field: "Age", Source: "Age", Conversion: "assign"},
+			{Field: "Email", Source: "Email", Skip: "destination value is kept"},
+			{Field: "Code", Source: "Code", Conversion: "parse"},
+		}},
+		{"map source", map[string]any{"Name": "n", "Code": "abc"}, &D{}, rejectName, []FieldAction{
+			{Field: "Name", Source: "Name", Skip: "skipped by When"},
+			{Field: "Age", Skip: "no matching source"},
+			{Field: "Email", Skip: "no matching source"},
+			{Field: "Code", Source: "Code", Conversion: "parse"},
+		}},
+		{"map destination", from, &map[string]string{"Email": "kept"}, KeepExisting(), []FieldAction{
+			{Field: "Name", Source: "Name", Conversion: "assign"},
+			{Field: "Age", Source: "Age", Conversion: "format"},
+			{Field: "Email", Source: "Email", Skip: "destination value is kept"},
+			{Field: "Code", Source: "Code", Conversion: "assign"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(tt.from, tt.to, tt.opt)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var FlattenDepth = 0
+
+// MaxUnflattenIndex bounds the slice indexes Unflatten accepts, since the
+// keys usually come from untrusted input such as form posts. MaxSliceLen
+// lowers the bound further when it is set.
+var MaxUnflattenIndex = 10000
+
+var ErrInvalidSource = errors.New("copy: source must be a struct or map")
+
+func Flatten(from any) (map[string]any, error) {
+	fromValue := indirectInterface(reflect.ValueOf(from))
+
+	if fromValue.Kind() != reflect.Struct && fromValue.Kind() != reflect.Map {
+		return nil, ErrInvalidSource
+	}
+
+	result := make(map[string]any)
+
+	flattenValue(result, "", fromValue, 0)
+
+	return result, nil
+}
+
+func flattenValue(result map[string]any, prefix string, fromValue reflect.Value, depth int) {
+	fromValue = indirectInterface(fromValue)
+
+	if prefix != "" && (!isFlattenable(fromValue) || FlattenDepth > 0 && depth >= FlattenDepth) {
+		if fromValue.IsValid() {
+			result[prefix] = fromValue.Interface()
+		} else {
+			result[prefix] = nil
+		}
+
+		return
+	}
+
+	switch fromValue.Kind() {
+	case reflect.Struct:
+		fromType := fromValue.Type()
+
+		for i := 0; i < fromType.NumField(); i++ {
+			fromField := fromType.Field(i)
+			fromTag := parseFieldTag(fromField)
+
+			if fromTag.ignore || !fromField.IsExported() {
+				continue
+			}
+
+			flattenValue(result, joinKey(prefix, fromTag.name), fromValue.Field(i), depth+1)
+		}
+	case reflect.Map:
+		kv := fromValue.MapRange()
+
+		for kv.Next() {
+			flattenValue(result, joinKey(prefix, fmt.Sprint(kv.Key())), kv.Value(), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fromValue.Len(); i++ {
+			flattenValue(result, joinKey(prefix, strconv.Itoa(i)), fromValue.Index(i), depth+1)
+		}
+	}
+}
+
+func isFlattenable(reflectValue reflect.Value) bool {
+	switch reflectValue.Kind() {
+	case reflect.Struct:
+		return reflectValue.Type() != reflect.TypeOf(time.Time{})
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return reflectValue.Len() > 0 && !isBytes(reflectValue.Type())
+	}
+
+	return false
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+type flatNode map[string]any
+
+func Unflatten(from map[string]any, to any) error {
+	toValue := reflect.ValueOf(to)
+
+	if !toValue.IsValid() || toValue.Kind() != reflect.Pointer || toValue.IsNil() {
+		return ErrInvalidDestination
+	}
+
+	root := flatNode{}
+
+	for k, v := range from {
+		node := root
+		keys := strings.Split(k, ".")
+
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(flatNode)
+
+			if !ok {
+				child = flatNode{}
+				node[key] = child
+			}
+
+			node = child
+		}
+
+		if _, ok := node[keys[len(keys)-1]].(flatNode); ok {
+			continue
+		}
+
+		node[keys[len(keys)-1]] = v
+	}
+
+	return unflattenValue(root, indirectValue(toValue))
+}
+
+func unflattenValue(node flatNode, toValue reflect.Value) error {
+	keys := make([]string, 0, len(node))
+
+	for k := range node {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var errs []error
+
+	switch toValue.Kind() {
+	case reflect.Struct:
+		for _, k := range keys {
+			toField, ok := fieldByKey(toValue.Type(), k)
+
+			if !ok {
+				continue
+			}
+
+			toFieldValue, ok := fieldByIndex(toValue, toField.Index)
+
+			if !ok || !toFieldValue.CanSet() {
+				continue
+			}
+
+			if err := unflattenChild(node[k], toFieldValue); err != nil {
+				errs = append(errs, &FieldError{Field: k, Err: err})
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		indexes := make(map[string]int, len(keys))
+		size := 0
+
+		for _, k := range keys {
+			i, err := strconv.Atoi(k)
+
+			if err != nil || i < 0 {
+				errs = append(errs, &FieldError{Field: k, Err: ErrUnknownField})
+
+				continue
+			}
+
+			if toValue.Kind() == reflect.Array && i >= toValue.Len() {
+				errs = append(errs, &FieldError{Field: k, Err: fmt.Errorf("%w: index %d out of range for %s", ErrLength, i, toValue.Type())})
+
+				continue
+			}
+
+			if limit := maxUnflattenIndex(); toValue.Kind() == reflect.Slice && i >= limit {
+				errs = append(errs, &FieldError{Field: k, Err: fmt.Errorf("%w: index %d exceeds the limit of %d", ErrLength, i, limit)})
+
+				continue
+			}
+
+			indexes[k] = i
+
+			if i >= size {
+				size = i + 1
+			}
+		}
+
+		if toValue.Kind() == reflect.Slice && size > toValue.Len() {
+			v := reflect.MakeSlice(toValue.Type(), size, size)
+			reflect.Copy(v, toValue)
+			toValue.Set(v)
+		}
+
+		for _, k := range keys {
+			if i, ok := indexes[k]; ok {
+				if err := unflattenChild(node[k], toValue.Index(i)); err != nil {
+					errs = append(errs, &FieldError{Field: k, Err: err})
+				}
+			}
+		}
+	case reflect.Map:
+		if toValue.IsNil() {
+			toValue.Set(reflect.MakeMap(toValue.Type()))
+		}
+
+		for _, k := range keys {
+			key := reflect.New(toValue.Type().Key()).Elem()
+
+			if ok, _ := copyValue(reflect.ValueOf(k), key); !ok {
+				continue
+			}
+
+			v := reflect.New(toValue.Type().Elem()).Elem()
+
+			if err := unflattenChild(node[k], v); err != nil {
+				errs = append(errs, &FieldError{Field: k, Err: err})
+
+				continue
+			}
+
+			toValue.SetMapIndex(key, v)
+		}
+	case reflect.Interface:
+		toValue.Set(reflect.ValueOf(node.plain()))
+	}
+
+	return errors.Join(errs...)
+}
+
+func maxUnflattenIndex() int {
+	if MaxSliceLen > 0 && MaxSliceLen < MaxUnflattenIndex {
+		return MaxSliceLen
+	}
+
+	return MaxUnflattenIndex
+}
+
+func unflattenChild(child any, toValue reflect.Value) error {
+	if toValue.Kind() == reflect.Pointer && toValue.IsNil() {
+		toValue.Set(reflect.New(indirectType(toValue.Type())))
+	}
+
+	if node, ok := child.(flatNode); ok {
+		return unflattenValue(node, indirectValue(toValue))
+	}
+
+	_, err := copyValue(reflect.ValueOf(child), toValue)
+
+	return err
+}
+
+func (n flatNode) plain() map[string]any {
+	result := make(map[string]any, len(n))
+
+	for k, v := range n {
+		if node, ok := v.(flatNode); ok {
+			result[k] = node.plain()
+		} else {
+			result[k] = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type flatAddress struct {
+	City string `copy:"city"`
+	Zip  string `copy:"zip"`
+}
+
+type flatItem struct {
+	Name string `copy:"name"`
+}
+
+type flatUser struct {
+	Name    string      `copy:"name"`
+	Address flatAddress `copy:"address"`
+	Items   []flatItem  `copy:"items"`
+	Secret  string      `copy:"-"`
+}
+
+func TestFlatten(t *testing.T) {
+	from := flatUser{
+		Name:    "ann",
+		Address: flatAddress{City: "x", Zip: "1"},
+		Items:   []flatItem{{Name: "a"}, {Name: "b"}},
+		Secret:  "s",
+	}
+
+	tests := []struct {
+		name  string
+		depth int
+		want  map[string]any
+	}{
+		{
+			name: "unlimited",
+			want: map[string]any{
+				"name":         "ann",
+				"address.city": "x",
+				"address.zip":  "1",
+				"items.0.name": "a",
+				"items.1.name": "b",
+			},
+		},
+		{
+			name:  "depth one",
+			depth: 1,
+			want: map[string]any{
+				"name":    "ann",
+				"address": flatAddress{City: "x", Zip: "1"},
+				"items":   []flatItem{{Name: "a"}, {Name: "b"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &FlattenDepth, tt.depth)
+
+			got, err := Flatten(from)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Flatten(1); err != ErrInvalidSource {
+		t.Fatalf("err = %v, want ErrInvalidSource", err)
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	var u flatUser
+
+	err := Unflatten(map[string]any{
+		"name":         "ann",
+		"address.city": "x",
+		"address.zip":  1,
+		"items.0.name": "a",
+		"items.2.name": "c",
+	}, &u)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := flatUser{
+		Name:    "ann",
+		Address: flatAddress{City: "x", Zip: "1"},
+		Items:   []flatItem{{Name: "a"}, {}, {Name: "c"}},
+	}
+
+	if !reflect.DeepEqual(u, want) {
+		t.Fatalf("got %+v, want %+v", u, want)
+	}
+}
+
+func TestUnflattenPromotedFields(t *testing.T) {
+	type Base struct {
+		ID      int
+		Address flatAddress
+	}
+
+	type User struct {
+		*Base
+		Name string `copy:"name"`
+	}
+
+	tests := []struct {
+		name string
+		from map[string]any
+		want User
+	}{
+		{"scalar", map[string]any{"ID": "7", "name": "ann"}, User{Base: &Base{ID: 7}, Name: "ann"}},
+		{"nested", map[string]any{"Address.city": "x"}, User{Base: &Base{Address: flatAddress{City: "x"}}}},
+		{"nothing promoted", map[string]any{"name": "ann"}, User{Name: "ann"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u User
+
+			if err := Unflatten(tt.from, &u); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(u, tt.want) {
+				t.Fatalf("got %+v, want %+v", u, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnflattenIndexLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		maxSliceLen int
+	}{
+		{"huge index", "items.9000000000000000000.name", 0},
+		{"above default limit", "items.10000.name", 0},
+		{"above MaxSliceLen", "items.5.name", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &MaxSliceLen, tt.maxSliceLen)
+
+			var u flatUser
+
+			err := Unflatten(map[string]any{tt.key: "x", "name": "ann"}, &u)
+
+			var fieldErr *FieldError
+
+			if !errors.Is(err, ErrLength) || !errors.As(err, &fieldErr) {
+				t.Fatalf("err = %v, want an ErrLength FieldError", err)
+			}
+
+			if u.Name != "ann" || len(u.Items) != 0 {
+				t.Fatalf("got %+v", u)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package copy
+
+import (
+	"math"
+	"strconv"
+)
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return NaNToken
+	case math.IsInf(f, 1):
+		return PosInfToken
+	case math.IsInf(f, -1):
+		return NegInfToken
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+func parseFloat(s string) (float64, error) {
+	switch s {
+	case NaNToken:
+		return math.NaN(), nil
+	case PosInfToken:
+		return math.Inf(1), nil
+	case NegInfToken:
+		return math.Inf(-1), nil
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
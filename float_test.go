@@ -0,0 +1,50 @@
+package copy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatTokens(t *testing.T) {
+	setVar(t, &NaNToken, "null")
+	setVar(t, &PosInfToken, "inf")
+	setVar(t, &NegInfToken, "-inf")
+
+	tests := []struct {
+		name  string
+		float float64
+		token string
+	}{
+		{"NaN", math.NaN(), "null"},
+		{"+Inf", math.Inf(1), "inf"},
+		{"-Inf", math.Inf(-1), "-inf"},
+		{"finite", 1.5, "1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, from := range []any{tt.float, float32(tt.float)} {
+				var s string
+
+				if err := CopyE(from, &s); err != nil {
+					t.Fatal(err)
+				}
+
+				if s != tt.token {
+					t.Fatalf("%T: got %q, want %q", from, s, tt.token)
+				}
+			}
+
+			var f float64
+
+			if err := CopyE(tt.token, &f); err != nil {
+				t.Fatal(err)
+			}
+
+			if f != tt.float && !(math.IsNaN(f) && math.IsNaN(tt.float)) {
+				t.Fatalf("got %v, want %v", f, tt.float)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,381 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var ErrUnconvertible = errors.New("copy: value cannot be converted")
+
+// IntoMapValue copies from into the value stored under key in m. Map
+// values are not addressable, so the current value is copied into a
+// temporary, updated and assigned back. m may be a map or a pointer to a
+// map; a nil map behind a pointer is allocated.
+func IntoMapValue(m any, key any, from any, opts ...Option) error {
+	return putInto(m, key, from, true, opts)
+}
+
+func AppendTo(to any, from any, opts ...Option) error {
+	toValue := reflect.ValueOf(to)
+
+	if toValue.Kind() != reflect.Pointer || toValue.IsNil() || toValue.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDestination
+	}
+
+	toValue = toValue.Elem()
+
+	v := reflect.New(toValue.Type().Elem()).Elem()
+
+	if err := copyInto(from, v, opts); err != nil {
+		return err
+	}
+
+	toValue.Set(reflect.Append(toValue, v))
+
+	return nil
+}
+
+// PutInto converts from and stores it under key in m, replacing any
+// current value. Unlike IntoMapValue the stored value is not updated in
+// place.
+func PutInto(m any, key any, from any, opts ...Option) error {
+	return putInto(m, key, from, false, opts)
+}
+
+func putInto(m any, key any, from any, update bool, opts []Option) error {
+	mValue := reflect.ValueOf(m)
+
+	if mValue.Kind() == reflect.Pointer && !mValue.IsNil() && mValue.Elem().Kind() == reflect.Map {
+		mValue = mValue.Elem()
+
+		if mValue.IsNil() {
+			mValue.Set(reflect.MakeMap(mValue.Type()))
+		}
+	}
+
+	if mValue.Kind() != reflect.Map || mValue.IsNil() {
+		return ErrInvalidDestination
+	}
+
+	k := reflect.New(mValue.Type().Key()).Elem()
+
+	if ok, err := copyValue(reflect.ValueOf(key), k); !ok {
+		if err == nil {
+			err = ErrMapKey
+		}
+
+		return err
+	}
+
+	v := reflect.New(mValue.Type().Elem()).Elem()
+
+	if existing := mValue.MapIndex(k); update && existing.IsValid() {
+		v.Set(existing)
+	}
+
+	if err := copyInto(from, v, opts); err != nil {
+		return err
+	}
+
+	mValue.SetMapIndex(k, v)
+
+	return nil
+}
+
+func copyInto(from any, toValue reflect.Value, opts []Option) error {
+	fromValue := reflect.ValueOf(from)
+
+	if !fromValue.IsValid() {
+		return nil
+	}
+
+	ok, err := newCopier(opts).copyValue(fromValue, toValue)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s into %s", ErrUnconvertible, fromValue.Type(), toValue.Type())
+	}
+
+	return nil
+}
+
+func NewPtr[T any](from any, opts ...Option) *T {
+	c := newCopier(opts)
+
+	if c.nilForNilSource && !indirectInterface(reflect.ValueOf(from)).IsValid() {
+		return nil
+	}
+
+	to := new(T)
+
+	_ = CopyE(from, to, opts...)
+
+	return to
+}
+
+func Applied[T any](base T, from any, opts ...Option) T {
+	to, _ := AppliedE(base, from, opts...)
+
+	return to
+}
+
+// AppliedE returns a deep copy of base with from copied over it. The copy
+// shares no maps, slices or pointers with base, so base is never modified.
+func AppliedE[T any](base T, from any, opts ...Option) (T, error) {
+	var to T
+
+	toValue := reflect.ValueOf(&to).Elem()
+	toValue.Set(deepClone(reflect.ValueOf(&base).Elem(), map[uintptr]reflect.Value{}))
+
+	if err := CopyE(from, &to, opts...); err != nil {
+		return to, err
+	}
+
+	return to, nil
+}
+
+func deepClone(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		if p, ok := seen[v.Pointer()]; ok {
+			return p
+		}
+
+		p := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = p
+		p.Elem().Set(deepClone(v.Elem(), seen))
+
+		return p
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		i := reflect.New(v.Type()).Elem()
+		i.Set(deepClone(v.Elem(), seen))
+
+		return i
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		kv := v.MapRange()
+
+		for kv.Next() {
+			m.SetMapIndex(kv.Key(), deepClone(kv.Value(), seen))
+		}
+
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		list := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			list.Index(i).Set(deepClone(v.Index(i), seen))
+		}
+
+		return list
+	case reflect.Array:
+		a := reflect.New(v.Type()).Elem()
+
+		for i := 0; i < v.Len(); i++ {
+			a.Index(i).Set(deepClone(v.Index(i), seen))
+		}
+
+		return a
+	case reflect.Struct:
+		// unexported fields are shared, as they cannot be set
+
+		s := reflect.New(v.Type()).Elem()
+		s.Set(v)
+
+		for i := 0; i < s.NumField(); i++ {
+			if s.Field(i).CanSet() {
+				s.Field(i).Set(deepClone(v.Field(i), seen))
+			}
+		}
+
+		return s
+	}
+
+	return v
+}
+
+func Merge(to any, sources ...any) error {
+	return MergeWith(to, sources)
+}
+
+// MergeWith is Merge with options, such as OnlyNonZero or KeepExisting,
+// applied to every source.
+func MergeWith(to any, sources []any, opts ...Option) error {
+	var errs []error
+
+	for _, from := range sources {
+		if err := CopyE(from, to, opts...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func SliceFunc[S any, D any](from []S, to *[]D, transform func(S) D) {
+	_ = SliceFuncE(from, to, func(s S) (D, error) {
+		return transform(s), nil
+	})
+}
+
+func SliceFuncE[S any, D any](from []S, to *[]D, transform func(S) (D, error)) error {
+	if to == nil {
+		return ErrInvalidDestination
+	}
+
+	if from == nil {
+		return nil
+	}
+
+	var errs []error
+
+	result := make([]D, 0, len(from))
+
+	for i, s := range from {
+		d, err := transform(s)
+
+		if err != nil {
+			errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+
+			continue
+		}
+
+		result = append(result, d)
+	}
+
+	*to = result
+
+	return errors.Join(errs...)
+}
+
+func SliceFilter[S any, D any](from []S, to *[]D, keep func(S) bool, opts ...Option) error {
+	if to == nil {
+		return ErrInvalidDestination
+	}
+
+	if from == nil {
+		return nil
+	}
+
+	var errs []error
+
+	c := newCopier(opts)
+	result := make([]D, 0, len(from))
+
+	for i, s := range from {
+		if !keep(s) {
+			continue
+		}
+
+		var d D
+
+		ok, err := c.at(strconv.Itoa(i)).copyValue(reflect.ValueOf(&s).Elem(), reflect.ValueOf(&d).Elem())
+
+		if err != nil {
+			errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+		}
+
+		if ok {
+			result = append(result, d)
+		}
+	}
+
+	*to = result
+
+	return errors.Join(errs...)
+}
+
+func Dispatch(from any, handlers map[reflect.Type]func(any)) bool {
+	fromValue := reflect.ValueOf(from)
+
+	for fromValue.IsValid() {
+		if handler, ok := handlers[fromValue.Type()]; ok {
+			handler(fromValue.Interface())
+
+			return true
+		}
+
+		if fromValue.Kind() != reflect.Pointer || fromValue.IsNil() {
+			break
+		}
+
+		fromValue = fromValue.Elem()
+	}
+
+	return false
+}
+
+func Pluck(from any, to any, field string) error {
+	fromValue := indirectInterface(reflect.ValueOf(from))
+	toValue := reflect.ValueOf(to)
+
+	if !isList(fromValue.Kind()) || indirectType(fromValue.Type().Elem()).Kind() != reflect.Struct {
+		return ErrInvalidSource
+	}
+
+	if toValue.Kind() != reflect.Pointer || toValue.IsNil() || toValue.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDestination
+	}
+
+	structField, ok := fieldByName(indirectType(fromValue.Type().Elem()), field)
+
+	if !ok || !structField.IsExported() {
+		return fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
+
+	toValue = toValue.Elem()
+	result := reflect.MakeSlice(toValue.Type(), 0, fromValue.Len())
+
+	var errs []error
+
+	for i := 0; i < fromValue.Len(); i++ {
+		elem := indirectInterface(fromValue.Index(i))
+
+		if !elem.IsValid() {
+			continue
+		}
+
+		fieldValue, err := elem.FieldByIndexErr(structField.Index)
+
+		if err != nil {
+			continue
+		}
+
+		v := reflect.New(toValue.Type().Elem()).Elem()
+
+		ok, err := copyValue(fieldValue, v)
+
+		if err != nil {
+			errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: err})
+		}
+
+		if ok {
+			result = reflect.Append(result, v)
+		}
+	}
+
+	toValue.Set(result)
+
+	return errors.Join(errs...)
+}
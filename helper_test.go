@@ -0,0 +1,514 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCopyIntoSliceElement(t *testing.T) {
+	type D struct {
+		Name string
+		Age  int
+	}
+
+	list := []D{{Name: "a", Age: 1}, {Name: "b", Age: 2}}
+
+	if err := CopyE(map[string]any{"Age": "5"}, &list[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if list[0] != (D{Name: "a", Age: 1}) || list[1] != (D{Name: "b", Age: 5}) {
+		t.Fatalf("got %+v", list)
+	}
+}
+
+func TestIntoMapValue(t *testing.T) {
+	type D struct {
+		Name string
+		Age  int
+	}
+
+	m := map[string]D{"ann": {Name: "ann", Age: 1}}
+
+	if err := IntoMapValue(m, "ann", map[string]any{"Age": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := IntoMapValue(&m, "bob", D{Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if m["ann"] != (D{Name: "ann", Age: 2}) || m["bob"] != (D{Name: "bob"}) {
+		t.Fatalf("got %+v", m)
+	}
+
+	var nilMap map[string]int
+
+	if err := IntoMapValue(&nilMap, "k", "3"); err != nil || nilMap["k"] != 3 {
+		t.Fatalf("got %v, %v", nilMap, err)
+	}
+
+	ints := map[int]int{}
+
+	if err := IntoMapValue(ints, "notanint", 5); !errors.Is(err, ErrMapKey) || len(ints) != 0 {
+		t.Fatalf("got %v, %v, want ErrMapKey", ints, err)
+	}
+
+	if err := IntoMapValue(map[string]int(nil), "k", 1); err != ErrInvalidDestination {
+		t.Fatalf("err = %v, want ErrInvalidDestination", err)
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	type U struct {
+		ID   int
+		Name string
+	}
+
+	tests := []struct {
+		name    string
+		to      any
+		from    any
+		want    any
+		wantErr error
+	}{
+		{"parsed scalar", &[]int{1}, "5", &[]int{1, 5}, nil},
+		{"converted float", &[]int{}, 6.0, &[]int{6}, nil},
+		{"map into struct", &[]U{}, map[string]any{"ID": "7"}, &[]U{{ID: 7}}, nil},
+		{"nil source appends zero", &[]int{1}, nil, &[]int{1, 0}, nil},
+		{"unparsable string", &[]int{}, "abc", &[]int{}, ErrUnconvertible},
+		{"slice into scalar", &[]int{}, []string{"a"}, &[]int{}, ErrUnconvertible},
+		{"not a pointer", []int{}, 1, []int{}, ErrInvalidDestination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AppendTo(tt.to, tt.from)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %v, want %v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutInto(t *testing.T) {
+	type U struct {
+		ID   int
+		Name string
+	}
+
+	tests := []struct {
+		name    string
+		m       map[int]U
+		key     any
+		from    any
+		want    map[int]U
+		wantErr error
+	}{
+		{"converted entry", map[int]U{}, "7", map[string]any{"ID": "7"}, map[int]U{7: {ID: 7}}, nil},
+		{"replaces current value", map[int]U{1: {ID: 1, Name: "old"}}, 1, map[string]any{"ID": 2}, map[int]U{1: {ID: 2}}, nil},
+		{"bad key", map[int]U{}, "x", U{}, map[int]U{}, ErrMapKey},
+		{"unconvertible value", map[int]U{}, 1, "abc", map[int]U{}, ErrUnconvertible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PutInto(&tt.m, tt.key, tt.from)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.m, tt.want) {
+				t.Fatalf("got %v, want %v", tt.m, tt.want)
+			}
+		})
+	}
+
+	var m map[string]int
+
+	if err := PutInto(&m, "k", "3"); err != nil || m["k"] != 3 {
+		t.Fatalf("got %v, %v", m, err)
+	}
+}
+
+func TestNewPtr(t *testing.T) {
+	type D struct {
+		Name string
+		Age  int
+	}
+
+	tests := []struct {
+		name    string
+		from    any
+		opts    []Option
+		want    *D
+		wantNil bool
+	}{
+		{"populated", map[string]any{"Name": "a", "Age": "3"}, nil, &D{Name: "a", Age: 3}, false},
+		{"nil source", nil, nil, &D{}, false},
+		{"nil source with option", nil, []Option{WithNilForNilSource()}, nil, true},
+		{"value with option", map[string]any{"Name": "b"}, []Option{WithNilForNilSource()}, &D{Name: "b"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPtr[D](tt.from, tt.opts...)
+
+			if (got == nil) != tt.wantNil || got != nil && *got != *tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	type Conf struct {
+		Host string
+		Port int
+		Name string
+	}
+
+	sources := []any{
+		Conf{Host: "a", Port: 1},
+		map[string]any{"Port": 0, "Name": "n"},
+		Conf{Host: "c"},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want Conf
+	}{
+		{"later sources win", nil, Conf{Host: "c"}},
+		{"only non-zero", []Option{OnlyNonZero()}, Conf{Host: "c", Port: 1, Name: "n"}},
+		{"keep existing", []Option{KeepExisting()}, Conf{Host: "a", Port: 1, Name: "n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Conf
+
+			if err := MergeWith(&d, sources, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+
+	var d Conf
+
+	if err := Merge(&d, sources...); err != nil || d != (Conf{Host: "c"}) {
+		t.Fatalf("Merge got %+v, %v", d, err)
+	}
+
+	if err := Merge(&d, Conf{Port: 2}, map[string]any{"Name": "m"}); err != nil || d != (Conf{Port: 2, Name: "m"}) {
+		t.Fatalf("Merge got %+v, %v", d, err)
+	}
+}
+
+func TestMergeOptionsArePerCall(t *testing.T) {
+	type Conf struct {
+		Host string
+		Port int
+	}
+
+	var wg sync.WaitGroup
+
+	results := make([]Conf, 2)
+
+	for i, opt := range []Option{OnlyNonZero(), KeepExisting()} {
+		wg.Add(1)
+
+		go func(i int, opt Option) {
+			defer wg.Done()
+
+			results[i] = Conf{Host: "h", Port: 1}
+
+			_ = MergeWith(&results[i], []any{Conf{Host: "x"}}, opt)
+		}(i, opt)
+	}
+
+	wg.Wait()
+
+	if results[0] != (Conf{Host: "x", Port: 1}) || results[1] != (Conf{Host: "h", Port: 1}) {
+		t.Fatalf("got %+v", results)
+	}
+}
+
+func TestSliceFunc(t *testing.T) {
+	var upper []string
+
+	SliceFunc([]string{"a", "b"}, &upper, strings.ToUpper)
+
+	if !reflect.DeepEqual(upper, []string{"A", "B"}) {
+		t.Fatalf("got %v", upper)
+	}
+
+	var n []int
+
+	err := SliceFuncE([]string{"1", "x", "3"}, &n, strconv.Atoi)
+
+	var fieldErr *FieldError
+
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "[1]" {
+		t.Fatalf("err = %v, want a FieldError for [1]", err)
+	}
+
+	if !reflect.DeepEqual(n, []int{1, 3}) {
+		t.Fatalf("got %v", n)
+	}
+
+	if err := SliceFuncE([]string{"1"}, nil, strconv.Atoi); err != ErrInvalidDestination {
+		t.Fatalf("err = %v, want ErrInvalidDestination", err)
+	}
+}
+
+func TestSentinelZero(t *testing.T) {
+	setVar(t, &SentinelZero, map[reflect.Type]any{reflect.TypeOf(0): -1, reflect.TypeOf(""): "N/A"})
+
+	type P struct {
+		Age  int
+		Name string
+	}
+
+	p := P{Age: 30, Name: "a"}
+
+	if err := MergeWith(&p, []any{P{Age: -1, Name: "N/A"}, map[string]any{"Age": -1}}, OnlyNonZero()); err != nil {
+		t.Fatal(err)
+	}
+
+	if p != (P{Age: 30, Name: "a"}) {
+		t.Fatalf("sentinels overwrote %+v", p)
+	}
+
+	if err := MergeWith(&p, []any{P{Age: 31}}, OnlyNonZero()); err != nil {
+		t.Fatal(err)
+	}
+
+	if p != (P{Age: 31, Name: "a"}) {
+		t.Fatalf("got %+v", p)
+	}
+}
+
+func TestApplied(t *testing.T) {
+	type Cfg struct {
+		Host  string
+		Port  int
+		Tags  map[string]string
+		Hosts []string
+		Next  *Cfg
+	}
+
+	newBase := func() Cfg {
+		return Cfg{Host: "h", Port: 80, Tags: map[string]string{"a": "1"}, Hosts: []string{"x"}, Next: &Cfg{Host: "n"}}
+	}
+
+	tests := []struct {
+		name    string
+		from    any
+		inPlace bool
+		want    Cfg
+	}{
+		{"scalar field", map[string]any{"Port": "8080"}, false, Cfg{Host: "h", Port: 8080, Tags: map[string]string{"a": "1"}, Hosts: []string{"x"}, Next: &Cfg{Host: "n"}}},
+		{"merged map", map[string]any{"Tags": map[string]any{"b": "2"}}, false, Cfg{Host: "h", Port: 80, Tags: map[string]string{"a": "1", "b": "2"}, Hosts: []string{"x"}, Next: &Cfg{Host: "n"}}},
+		{"in-place slice", map[string]any{"Hosts": []any{"y"}}, true, Cfg{Host: "h", Port: 80, Tags: map[string]string{"a": "1"}, Hosts: []string{"y"}, Next: &Cfg{Host: "n"}}},
+		{"pointer field", map[string]any{"Next": map[string]any{"Host": "m"}}, false, Cfg{Host: "h", Port: 80, Tags: map[string]string{"a": "1"}, Hosts: []string{"x"}, Next: &Cfg{Host: "m"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &InPlaceSlice, tt.inPlace)
+
+			base := newBase()
+
+			got, err := AppliedE(base, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(base, newBase()) {
+				t.Fatalf("base changed to %+v", base)
+			}
+		})
+	}
+
+	if n := Applied(3, "4"); n != 4 {
+		t.Fatalf("scalar = %d", n)
+	}
+
+	setVar(t, &CheckedConversions, true)
+
+	type Small struct {
+		N int8
+	}
+
+	if got, err := AppliedE(Small{N: 1}, map[string]any{"N": 300}); !errors.Is(err, ErrOverflow) || got.N != 1 {
+		t.Fatalf("got %+v, %v, want ErrOverflow", got, err)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	type Cat struct {
+		Name string
+	}
+
+	type Dog struct {
+		Name string
+		Bark bool
+	}
+
+	type Animal struct {
+		Name string
+		Kind string
+	}
+
+	var out []Animal
+
+	handle := func(kind string) func(any) {
+		return func(v any) {
+			var a Animal
+
+			Copy(v, &a)
+
+			a.Kind = kind
+			out = append(out, a)
+		}
+	}
+
+	handlers := map[reflect.Type]func(any){
+		reflect.TypeOf(Cat{}): handle("cat"),
+		reflect.TypeOf(Dog{}): handle("dog"),
+	}
+
+	var handled []bool
+
+	for _, v := range []any{Cat{Name: "c"}, &Dog{Name: "d", Bark: true}, 3, nil} {
+		handled = append(handled, Dispatch(v, handlers))
+	}
+
+	if !reflect.DeepEqual(handled, []bool{true, true, false, false}) {
+		t.Fatalf("handled = %v", handled)
+	}
+
+	if !reflect.DeepEqual(out, []Animal{{Name: "c", Kind: "cat"}, {Name: "d", Kind: "dog"}}) {
+		t.Fatalf("got %+v", out)
+	}
+}
+
+func TestPluck(t *testing.T) {
+	type U struct {
+		Name   string `copy:"name"`
+		Age    int
+		secret string
+	}
+
+	users := []U{{"a", 1, "x"}, {"b", 2, "y"}}
+
+	tests := []struct {
+		name    string
+		from    any
+		to      any
+		field   string
+		want    any
+		wantErr error
+	}{
+		{"string field", users, &[]string{}, "Name", &[]string{"a", "b"}, nil},
+		{"int field into strings", users, &[]string{}, "Age", &[]string{"1", "2"}, nil},
+		{"by tag", users, &[]string{}, "name", &[]string{"a", "b"}, nil},
+		{"pointer elements skip nil", []*U{{Name: "a"}, nil, {Name: "b"}}, &[]string{}, "Name", &[]string{"a", "b"}, nil},
+		{"unknown field", users, &[]string{}, "X", &[]string{}, ErrUnknownField},
+		{"unexported field", users, &[]string{}, "secret", &[]string{}, ErrUnknownField},
+		{"not a slice of structs", []int{1}, &[]string{}, "Name", &[]string{}, ErrInvalidSource},
+		{"not a pointer", users, []string{}, "Name", []string{}, ErrInvalidDestination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Pluck(tt.from, tt.to, tt.field)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %v, want %v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliceFilter(t *testing.T) {
+	type S struct {
+		ID     int
+		Active bool
+	}
+
+	type D struct {
+		ID string
+	}
+
+	from := []S{{1, true}, {2, false}, {3, true}}
+
+	tests := []struct {
+		name string
+		keep func(S) bool
+		want []D
+	}{
+		{"active only", func(s S) bool { return s.Active }, []D{{"1"}, {"3"}}},
+		{"inactive only", func(s S) bool { return !s.Active }, []D{{"2"}}},
+		{"none", func(S) bool { return false }, []D{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := []D{{"old"}}
+
+			if err := SliceFilter(from, &to, tt.keep); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(to, tt.want) {
+				t.Fatalf("got %+v, want %+v", to, tt.want)
+			}
+		})
+	}
+
+	var ptrs []*D
+
+	if err := SliceFilter(from, &ptrs, func(s S) bool { return s.ID > 1 }); err != nil || len(ptrs) != 2 || *ptrs[0] != (D{"2"}) || *ptrs[1] != (D{"3"}) {
+		t.Fatalf("got %+v, %v", ptrs, err)
+	}
+
+	setVar(t, &CheckedConversions, true)
+
+	var small []int8
+
+	err := SliceFilter([]int{1, 300, 3}, &small, func(int) bool { return true })
+
+	var fieldErr *FieldError
+
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "[1]" || !reflect.DeepEqual(small, []int8{1, 3}) {
+		t.Fatalf("got %v, %v, want a FieldError for [1]", small, err)
+	}
+
+	if err := SliceFilter[S, D](from, nil, nil); err != ErrInvalidDestination {
+		t.Fatalf("err = %v, want ErrInvalidDestination", err)
+	}
+}
@@ -0,0 +1,75 @@
+package copy
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	postHooksMu sync.RWMutex
+	postHooks   = map[reflect.Type]func(reflect.Value){}
+)
+
+func RegisterPostHook(reflectType reflect.Type, hook func(reflect.Value)) {
+	postHooksMu.Lock()
+	defer postHooksMu.Unlock()
+
+	if hook == nil {
+		delete(postHooks, reflectType)
+
+		return
+	}
+
+	postHooks[reflectType] = hook
+}
+
+func hasPostHooks() bool {
+	postHooksMu.RLock()
+	defer postHooksMu.RUnlock()
+
+	return len(postHooks) > 0
+}
+
+func runPostHook(toValue reflect.Value) {
+	toValue = indirectValue(toValue)
+
+	if !toValue.IsValid() {
+		return
+	}
+
+	postHooksMu.RLock()
+	hook, ok := postHooks[toValue.Type()]
+	postHooksMu.RUnlock()
+
+	if ok {
+		hook(toValue)
+	}
+}
+
+// CopyContext describes a struct copy to an AfterCopier. Fields maps each
+// destination field name to the source field or map key it was matched
+// with; Skipped lists the source fields and keys that matched nothing.
+type CopyContext struct {
+	Fields  map[string]string
+	Skipped []string
+}
+
+// AfterCopier is implemented by destination structs that validate or
+// adjust themselves once their fields have been copied. It runs only when
+// a struct is copied field by field, not when a value of the same type is
+// assigned whole.
+type AfterCopier interface {
+	AfterCopy(CopyContext) error
+}
+
+var afterCopierType = reflect.TypeOf((*AfterCopier)(nil)).Elem()
+
+func (c *copier) afterCopier(toValue reflect.Value) (AfterCopier, bool) {
+	if c.dryRun || !toValue.CanAddr() || !toValue.Addr().CanInterface() {
+		return nil, false
+	}
+
+	hook, ok := toValue.Addr().Interface().(AfterCopier)
+
+	return hook, ok
+}
@@ -0,0 +1,104 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type email string
+
+func TestRegisterPostHook(t *testing.T) {
+	RegisterPostHook(reflect.TypeOf(email("")), func(v reflect.Value) {
+		v.SetString(strings.ToLower(strings.TrimSpace(v.String())))
+	})
+
+	t.Cleanup(func() {
+		RegisterPostHook(reflect.TypeOf(email("")), nil)
+	})
+
+	type S struct {
+		Email   string
+		Backup  string
+		Contact string
+	}
+
+	type D struct {
+		Email   email
+		Backup  *email
+		Contact string
+	}
+
+	var d D
+
+	Copy(S{Email: "  Ann@Example.COM ", Backup: "B@X.io", Contact: " Keep "}, &d)
+
+	if d.Email != "ann@example.com" || d.Backup == nil || *d.Backup != "b@x.io" || d.Contact != " Keep " {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+var errRange = errors.New("begin after end")
+
+type rangeDest struct {
+	Begin int `copy:"Start"`
+	End   int
+	ctx   CopyContext
+}
+
+func (d *rangeDest) AfterCopy(ctx CopyContext) error {
+	d.ctx = ctx
+
+	if _, ok := ctx.Fields["Begin"]; ok && d.Begin > d.End {
+		return errRange
+	}
+
+	return nil
+}
+
+func TestAfterCopier(t *testing.T) {
+	type S struct {
+		Start, End int
+		Notes      string
+	}
+
+	tests := []struct {
+		name        string
+		from        any
+		wantFields  map[string]string
+		wantSkipped []string
+		wantErr     error
+	}{
+		{"struct source", S{1, 2, "x"}, map[string]string{"Begin": "Start", "End": "End"}, []string{"Notes"}, nil},
+		{"map source", map[string]any{"Start": 1, "End": 2, "x": 1}, map[string]string{"Begin": "Start", "End": "End"}, []string{"x"}, nil},
+		{"partial source", struct{ End int }{2}, map[string]string{"End": "End"}, nil, nil},
+		{"validation fails", S{3, 2, ""}, map[string]string{"Begin": "Start", "End": "End"}, []string{"Notes"}, errRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d rangeDest
+
+			if err := CopyE(tt.from, &d); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(d.ctx.Fields, tt.wantFields) || !reflect.DeepEqual(d.ctx.Skipped, tt.wantSkipped) {
+				t.Fatalf("got %+v, want fields %v and skipped %v", d.ctx, tt.wantFields, tt.wantSkipped)
+			}
+		})
+	}
+
+	var list []rangeDest
+
+	if err := CopyE([]S{{1, 2, ""}}, &list); err != nil || len(list) != 1 || list[0].ctx.Fields["End"] != "End" {
+		t.Fatalf("got %+v, %v", list, err)
+	}
+
+	var nested struct{ R rangeDest }
+
+	if err := CopyE(struct{ R S }{S{3, 2, ""}}, &nested); !errors.Is(err, errRange) {
+		t.Fatalf("err = %v, want errRange from a nested destination", err)
+	}
+}
@@ -0,0 +1,51 @@
+package copy
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func copyJSON(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	switch {
+	case toValue.Type() == rawMessageType && fromValue.Type() != rawMessageType:
+		if fromValue.Kind() == reflect.String {
+			toValue.SetBytes([]byte(fromValue.String()))
+
+			return true, nil
+		}
+
+		if !fromValue.CanInterface() {
+			return false, nil
+		}
+
+		b, err := json.Marshal(fromValue.Interface())
+
+		if err != nil {
+			return false, err
+		}
+
+		toValue.SetBytes(b)
+
+		return true, nil
+	case fromValue.Type() == rawMessageType && toValue.Type() != rawMessageType:
+		if toValue.Kind() == reflect.String {
+			toValue.Set(reflect.ValueOf(string(fromValue.Bytes())).Convert(toValue.Type()))
+
+			return true, nil
+		}
+
+		if !toValue.CanAddr() || fromValue.Len() == 0 {
+			return false, nil
+		}
+
+		if err := json.Unmarshal(fromValue.Bytes(), toValue.Addr().Interface()); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,77 @@
+package copy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRawJSON(t *testing.T) {
+	type Inner struct {
+		A int
+		B []string
+	}
+
+	type Src struct {
+		Data Inner
+		M    map[string]any
+	}
+
+	type Mid struct {
+		Data json.RawMessage
+		M    json.RawMessage
+	}
+
+	type Dst struct {
+		Data *Inner
+		M    map[string]any
+	}
+
+	from := Src{Data: Inner{A: 1, B: []string{"x"}}, M: map[string]any{"k": "v"}}
+
+	t.Run("disabled", func(t *testing.T) {
+		var m Mid
+
+		Copy(from, &m)
+
+		if m.Data != nil {
+			t.Fatalf("marshaled %s without RawJSON", m.Data)
+		}
+	})
+
+	setVar(t, &RawJSON, true)
+
+	var m Mid
+
+	if err := CopyE(from, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(m.Data) != `{"A":1,"B":["x"]}` || string(m.M) != `{"k":"v"}` {
+		t.Fatalf("marshaled %s and %s", m.Data, m.M)
+	}
+
+	var d Dst
+
+	if err := CopyE(m, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Data == nil || !reflect.DeepEqual(*d.Data, from.Data) || d.M["k"] != "v" {
+		t.Fatalf("unmarshaled %+v", d)
+	}
+
+	var s struct{ Data string }
+
+	if err := CopyE(m, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Data != `{"A":1,"B":["x"]}` {
+		t.Fatalf("string = %q", s.Data)
+	}
+
+	if err := CopyE(Mid{Data: json.RawMessage(`{`)}, &Dst{}); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
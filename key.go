@@ -0,0 +1,65 @@
+package copy
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+func (c *copier) copyKey(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+
+	if !fromValue.IsValid() || !fromValue.CanInterface() {
+		return false, nil
+	}
+
+	if _, ok := fromValue.Interface().(encoding.TextMarshaler); ok || fromValue.Kind() == reflect.String {
+		if v, ok := reflect.New(toValue.Type()).Interface().(encoding.TextUnmarshaler); ok && !fromValue.Type().AssignableTo(toValue.Type()) {
+			if err := v.UnmarshalText([]byte(keyString(fromValue))); err != nil {
+				return false, err
+			}
+
+			toValue.Set(reflect.ValueOf(v).Elem())
+
+			return true, nil
+		}
+	}
+
+	if v, ok := fromValue.Interface().(encoding.TextMarshaler); ok && toValue.Kind() == reflect.String {
+		b, err := v.MarshalText()
+
+		if err != nil {
+			return false, err
+		}
+
+		toValue.Set(reflect.ValueOf(string(b)).Convert(toValue.Type()))
+
+		return true, nil
+	}
+
+	ok, err := c.copyValue(fromValue, toValue)
+
+	if !ok && err == nil && StrictKeys {
+		err = ErrMapKey
+	}
+
+	return ok, err
+}
+
+func keyString(reflectValue reflect.Value) string {
+	reflectValue = indirectInterface(reflectValue)
+
+	if reflectValue.Kind() == reflect.String {
+		return reflectValue.String()
+	}
+
+	if reflectValue.IsValid() && reflectValue.CanInterface() {
+		if v, ok := reflectValue.Interface().(encoding.TextMarshaler); ok {
+			if b, err := v.MarshalText(); err == nil {
+				return string(b)
+			}
+		}
+	}
+
+	return fmt.Sprint(reflectValue)
+}
@@ -0,0 +1,107 @@
+package copy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type textID struct {
+	V string
+}
+
+func (i textID) MarshalText() ([]byte, error) {
+	return []byte("id:" + i.V), nil
+}
+
+func (i *textID) UnmarshalText(b []byte) error {
+	if string(b) == "bad" {
+		return errors.New("bad id")
+	}
+
+	i.V = strings.TrimPrefix(string(b), "id:")
+
+	return nil
+}
+
+func TestTextMarshalerMapKeys(t *testing.T) {
+	type S struct {
+		Name string
+		Age  int
+	}
+
+	m := map[textID]any{}
+
+	if err := CopyE(S{Name: "n", Age: 3}, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m) != 2 || m[textID{"Name"}] != "n" || m[textID{"Age"}] != 3 {
+		t.Fatalf("struct to map = %v", m)
+	}
+
+	strs := map[string]int{}
+
+	if err := CopyE(map[textID]int{{"x"}: 1}, &strs); err != nil {
+		t.Fatal(err)
+	}
+
+	if strs["id:x"] != 1 {
+		t.Fatalf("marshaled keys = %v", strs)
+	}
+
+	ids := map[textID]int{}
+
+	if err := CopyE(strs, &ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids[textID{"x"}] != 1 {
+		t.Fatalf("unmarshaled keys = %v", ids)
+	}
+
+	if err := CopyE(map[string]int{"bad": 1}, &map[textID]int{}); err == nil {
+		t.Fatal("expected an UnmarshalText error")
+	}
+}
+
+func TestMapKeyAndValueConversion(t *testing.T) {
+	type S struct {
+		Name string
+		Age  string
+	}
+
+	type D struct {
+		Name string
+		Age  int
+	}
+
+	from := map[string]S{"42": {Name: "a", Age: "3"}, "x": {Name: "b", Age: "4"}}
+
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr bool
+	}{
+		{"lenient", false, false},
+		{"strict", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &StrictKeys, tt.strict)
+
+			var d map[int]D
+
+			err := CopyE(from, &d)
+
+			if errors.Is(err, ErrMapKey) != tt.wantErr {
+				t.Fatalf("err = %v, want ErrMapKey %v", err, tt.wantErr)
+			}
+
+			if len(d) != 1 || d[42] != (D{Name: "a", Age: 3}) {
+				t.Fatalf("got %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package copy
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+type fieldMatch struct {
+	from    reflect.StructField
+	fromTag fieldTag
+	to      reflect.StructField
+	ok      bool
+}
+
+func (c *copier) structMatches(fromType reflect.Type, toType reflect.Type) []fieldMatch {
+	var matches []fieldMatch
+	var covered [][]int
+
+	for _, fromField := range visibleFields(fromType) {
+		if isCovered(fromField.Index, covered) {
+			continue
+		}
+
+		fromTag := parseFieldTag(fromField)
+
+		if fromTag.ignore {
+			covered = append(covered, fromField.Index)
+
+			continue
+		}
+
+		if c.only != nil && !c.only[fromField.Name] {
+			continue
+		}
+
+		toField, ok := c.matchField(fromField, fromTag, toType)
+
+		if ok && fromField.Anonymous {
+			// promoted fields are copied with the embedded struct
+
+			covered = append(covered, fromField.Index)
+		}
+
+		matches = append(matches, fieldMatch{from: fromField, fromTag: fromTag, to: toField, ok: ok})
+	}
+
+	return matches
+}
+
+var visibleFieldsCache sync.Map
+
+func visibleFields(structType reflect.Type) []reflect.StructField {
+	if v, ok := visibleFieldsCache.Load(structType); ok {
+		return v.([]reflect.StructField)
+	}
+
+	fields := reflect.VisibleFields(structType)
+
+	visibleFieldsCache.Store(structType, fields)
+
+	return fields
+}
+
+func isCovered(index []int, covered [][]int) bool {
+	for _, prefix := range covered {
+		if len(prefix) < len(index) && slicesEqual(prefix, index[:len(prefix)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func slicesEqual(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fieldByIndex(structValue reflect.Value, index []int) (reflect.Value, bool) {
+	v := structValue
+
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+func (c *copier) structChoices(matches []fieldMatch) (map[string]string, []error) {
+	candidates := map[string][]string{}
+
+	for _, m := range matches {
+		if m.ok {
+			candidates[m.to.Name] = append(candidates[m.to.Name], m.from.Name)
+		}
+	}
+
+	return c.choose(candidates)
+}
+
+func (c *copier) mapChoices(fromValue reflect.Value, toType reflect.Type) (map[string]string, []error) {
+	candidates := map[string][]string{}
+
+	kv := fromValue.MapRange()
+
+	for kv.Next() {
+		key := keyString(kv.Key())
+		name := key
+
+		if mapped, ok := c.mapping[key]; ok {
+			name = mapped
+		}
+
+		if toField, ok := fieldByMapKey(toType, kv.Key(), name); ok {
+			candidates[toField.Name] = append(candidates[toField.Name], key)
+		}
+	}
+
+	for _, keys := range candidates {
+		sort.Strings(keys)
+	}
+
+	return c.choose(candidates)
+}
+
+func (c *copier) choose(candidates map[string][]string) (map[string]string, []error) {
+	var chosen map[string]string
+	var errs []error
+
+	for name, sources := range candidates {
+		if len(sources) < 2 {
+			continue
+		}
+
+		if chosen == nil {
+			chosen = map[string]string{}
+		}
+
+		if c.onAmbiguous != nil {
+			chosen[name] = c.onAmbiguous(c.at(name).path, sources)
+
+			continue
+		}
+
+		if StrictMatching {
+			errs = append(errs, &FieldError{Field: name, Err: ErrAmbiguous})
+		}
+
+		chosen[name] = sources[0]
+	}
+
+	return chosen, errs
+}
@@ -0,0 +1,183 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOnAmbiguous(t *testing.T) {
+	type S struct {
+		UserName string
+		Login    string `copy:"UserName"`
+	}
+
+	type D struct {
+		UserName string
+	}
+
+	from := S{UserName: "u", Login: "l"}
+
+	t.Run("first declared wins", func(t *testing.T) {
+		var d D
+
+		if err := CopyE(from, &d); err != nil {
+			t.Fatal(err)
+		}
+
+		if d.UserName != "u" {
+			t.Fatalf("got %+v", d)
+		}
+	})
+
+	t.Run("callback chooses", func(t *testing.T) {
+		var d D
+		var candidates []string
+
+		err := CopyE(from, &d, OnAmbiguous(func(toField string, c []string) string {
+			candidates = c
+
+			return "Login"
+		}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if d.UserName != "l" || !reflect.DeepEqual(candidates, []string{"UserName", "Login"}) {
+			t.Fatalf("got %+v from %v", d, candidates)
+		}
+	})
+
+	t.Run("strict matching", func(t *testing.T) {
+		setVar(t, &StrictMatching, true)
+
+		var d D
+
+		if err := CopyE(from, &d); !errors.Is(err, ErrAmbiguous) {
+			t.Fatalf("err = %v, want ErrAmbiguous", err)
+		}
+	})
+
+	t.Run("strict matching on map keys", func(t *testing.T) {
+		setVar(t, &StrictMatching, true)
+		setVar(t, &JSONTagFallback, true)
+
+		var d struct {
+			UserName string `json:"user_name"`
+		}
+
+		err := CopyE(map[string]any{"UserName": "a", "user_name": "b"}, &d)
+
+		if !errors.Is(err, ErrAmbiguous) || d.UserName != "a" {
+			t.Fatalf("got %+v, err = %v", d, err)
+		}
+	})
+}
+
+func TestPromotedFields(t *testing.T) {
+	type Base struct {
+		ID   int
+		Name string
+	}
+
+	type Audit struct {
+		Name string
+		By   string
+	}
+
+	type Src struct {
+		Base
+		*Audit
+		Name string
+	}
+
+	type Flat struct {
+		ID   int
+		Name string
+		By   string
+	}
+
+	type Emb struct {
+		*Base
+		Name string
+	}
+
+	t.Run("shadowed names", func(t *testing.T) {
+		var f Flat
+
+		Copy(Src{Base: Base{ID: 1, Name: "base"}, Audit: &Audit{Name: "audit", By: "me"}, Name: "outer"}, &f)
+
+		if f != (Flat{ID: 1, Name: "outer", By: "me"}) {
+			t.Fatalf("got %+v", f)
+		}
+	})
+
+	t.Run("nil embedded pointer", func(t *testing.T) {
+		var f Flat
+
+		Copy(Src{Base: Base{ID: 2, Name: "b"}}, &f)
+
+		if f != (Flat{ID: 2}) {
+			t.Fatalf("got %+v", f)
+		}
+	})
+
+	t.Run("into embedded pointer", func(t *testing.T) {
+		var e Emb
+
+		Copy(Flat{ID: 3, Name: "n", By: "x"}, &e)
+
+		if e.Base == nil || e.ID != 3 || e.Name != "n" || e.Base.Name != "" {
+			t.Fatalf("got %+v", e)
+		}
+	})
+
+	t.Run("map into embedded pointer", func(t *testing.T) {
+		tests := []struct {
+			name string
+			from map[string]any
+			want Base
+		}{
+			{"promoted field", map[string]any{"ID": 1}, Base{ID: 1}},
+			{"promoted and outer field", map[string]any{"ID": "2", "Name": "n"}, Base{ID: 2}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var e Emb
+
+				Copy(tt.from, &e)
+
+				if e.Base == nil || *e.Base != tt.want {
+					t.Fatalf("got %+v", e)
+				}
+			})
+		}
+	})
+
+	t.Run("embedded struct copied whole", func(t *testing.T) {
+		var e Emb
+
+		Copy(Src{Base: Base{ID: 4, Name: "b"}, Name: "o"}, &e)
+
+		if e.Base == nil || *e.Base != (Base{ID: 4, Name: "b"}) || e.Name != "o" {
+			t.Fatalf("got %+v", e)
+		}
+	})
+
+	t.Run("ignored embedded struct", func(t *testing.T) {
+		type Hidden struct {
+			Base `copy:"-"`
+			Name string
+		}
+
+		var f Flat
+
+		Copy(Hidden{Base: Base{ID: 5, Name: "z"}, Name: "q"}, &f)
+
+		if f != (Flat{Name: "q"}) {
+			t.Fatalf("got %+v", f)
+		}
+	})
+}
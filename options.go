@@ -0,0 +1,108 @@
+package copy
+
+import (
+	"reflect"
+)
+
+type Option func(*options)
+
+type options struct {
+	mapping map[string]string
+	only    map[string]bool
+
+	when   func(string, reflect.Value) bool
+	rename func(string) string
+
+	onAmbiguous     func(string, []string) string
+	keyTransform    func(any) any
+	stringNormalize func(string) string
+
+	nilForNilSource bool
+	onlyNonZero     bool
+	keepExisting    bool
+}
+
+type copier struct {
+	options
+
+	path string
+
+	// dryRun keeps post hooks, CopyFrom methods and accessors from running
+	// while Explain tries a copy.
+	dryRun bool
+}
+
+func newCopier(opts []Option) *copier {
+	c := &copier{}
+
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+
+	return c
+}
+
+func (c *copier) at(name string) *copier {
+	child := *c
+	child.path = joinKey(c.path, name)
+
+	return &child
+}
+
+func (c *copier) fieldsUnchanged() bool {
+	return len(c.mapping) == 0 && c.only == nil && c.when == nil && c.rename == nil && c.keyTransform == nil && c.stringNormalize == nil && !c.onlyNonZero && !c.keepExisting
+}
+
+func WithMapping(mapping map[string]string) Option {
+	return func(o *options) {
+		o.mapping = mapping
+	}
+}
+
+func OnlyNonZero() Option {
+	return func(o *options) {
+		o.onlyNonZero = true
+	}
+}
+
+func KeepExisting() Option {
+	return func(o *options) {
+		o.keepExisting = true
+	}
+}
+
+func WithNilForNilSource() Option {
+	return func(o *options) {
+		o.nilForNilSource = true
+	}
+}
+
+func When(when func(path string, from reflect.Value) bool) Option {
+	return func(o *options) {
+		o.when = when
+	}
+}
+
+func Rename(rename func(toField string) (fromField string)) Option {
+	return func(o *options) {
+		o.rename = rename
+	}
+}
+
+func OnAmbiguous(onAmbiguous func(toField string, candidates []string) (chosen string)) Option {
+	return func(o *options) {
+		o.onAmbiguous = onAmbiguous
+	}
+}
+
+func KeyTransform(keyTransform func(key any) any) Option {
+	return func(o *options) {
+		o.keyTransform = keyTransform
+	}
+}
+
+func StringNormalize(normalize func(string) string) Option {
+	return func(o *options) {
+		o.stringNormalize = normalize
+	}
+}
@@ -0,0 +1,248 @@
+package copy
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWithMapping(t *testing.T) {
+	type S struct {
+		Login string
+		Name  string
+		Email string
+	}
+
+	type D struct {
+		Username string
+		Name     string
+		Login    string
+	}
+
+	var d D
+
+	if err := CopyE(S{Login: "ann", Name: "Ann", Email: "a@b"}, &d, WithMapping(map[string]string{"Login": "Username"})); err != nil {
+		t.Fatal(err)
+	}
+
+	if d != (D{Username: "ann", Name: "Ann"}) {
+		t.Fatalf("got %+v", d)
+	}
+
+	m := map[string]any{}
+
+	Copy(S{Login: "ann"}, &m, WithMapping(map[string]string{"Login": "user"}))
+
+	if m["user"] != "ann" || m["Login"] != nil {
+		t.Fatalf("got %v", m)
+	}
+}
+
+func TestRename(t *testing.T) {
+	type S struct {
+		Name   string
+		Age    int
+		ID     int
+		Secret string
+	}
+
+	type D struct {
+		NameField   string
+		AgeField    int
+		ID          int
+		SecretField string `copy:"-"`
+	}
+
+	tests := []struct {
+		name   string
+		rename func(string) string
+		want   D
+	}{
+		{"suffix", func(field string) string { return strings.TrimSuffix(field, "Field") }, D{NameField: "a", AgeField: 3, ID: 7}},
+		{"no match", strings.ToLower, D{ID: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			if err := CopyE(S{Name: "a", Age: 3, ID: 7, Secret: "s"}, &d, Rename(tt.rename)); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyTransform(t *testing.T) {
+	lower := func(key any) any {
+		if s, ok := key.(string); ok {
+			return strings.ToLower(s)
+		}
+
+		return key
+	}
+
+	tests := []struct {
+		name      string
+		from      any
+		to        any
+		transform func(key any) any
+		want      any
+	}{
+		{"lowercase", map[string]int{"A": 1, "Bc": 2}, &map[string]int{}, lower, &map[string]int{"a": 1, "bc": 2}},
+		{"prefix then convert", map[int]int{1: 1, 2: 2}, &map[string]string{}, func(key any) any {
+			return "k" + strings.Repeat("x", key.(int))
+		}, &map[string]string{"kx": "1", "kxx": "2"}},
+		{"scale then convert", map[int]int{1: 1, 2: 2}, &map[string]int{}, func(key any) any {
+			return key.(int) * 10
+		}, &map[string]int{"10": 1, "20": 2}},
+		{"nil drops the entry", map[string]int{"a": 1, "drop": 2}, &map[string]int{}, func(key any) any {
+			if key == "drop" {
+				return nil
+			}
+
+			return key
+		}, &map[string]int{"a": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to, KeyTransform(tt.transform)); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %v, want %v", tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringNormalize(t *testing.T) {
+	type Name string
+
+	type In struct {
+		A string
+		L []string
+		M map[string]string
+		N Name
+		P *string
+	}
+
+	type Out struct {
+		Top string
+		In  In
+		Num string
+	}
+
+	p := " p "
+	from := Out{" t ", In{" a ", []string{" x "}, map[string]string{"k": " v "}, " n ", &p}, ""}
+
+	tests := []struct {
+		name      string
+		normalize func(string) string
+		want      Out
+	}{
+		{"trim", strings.TrimSpace, Out{"t", In{"a", []string{"x"}, map[string]string{"k": "v"}, "n", nil}, ""}},
+		{"upper keys too", strings.ToUpper, Out{" T ", In{" A ", []string{" X "}, map[string]string{"K": " V "}, " N ", nil}, ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Out
+
+			if err := CopyE(from, &got, StringNormalize(tt.normalize)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.In.P == nil || *got.In.P != tt.normalize(p) {
+				t.Fatalf("got P %v, want %q", got.In.P, tt.normalize(p))
+			}
+
+			got.In.P = nil
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if from.In.A != " a " || from.In.L[0] != " x " || from.In.M["k"] != " v " || p != " p " {
+		t.Fatalf("source modified: %+v", from)
+	}
+
+	var s string
+
+	if err := CopyE(" z ", &s, StringNormalize(strings.TrimSpace)); err != nil || s != "z" {
+		t.Fatalf("got %q, %v", s, err)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	type Inner struct {
+		Field string
+		Keep  string
+	}
+
+	type S struct {
+		Name  string
+		Age   int
+		Inner Inner
+		Tags  map[string]string
+	}
+
+	from := S{Name: "new", Age: 2, Inner: Inner{Field: "new", Keep: "new"}, Tags: map[string]string{"a": "new", "b": "new"}}
+	old := func() S {
+		return S{Name: "old", Age: 1, Inner: Inner{Field: "old", Keep: "old"}}
+	}
+
+	tests := []struct {
+		name   string
+		reject map[string]bool
+		want   S
+		paths  []string
+	}{
+		{"top level field", map[string]bool{"Name": true}, S{"old", 2, Inner{"new", "new"}, map[string]string{"a": "new", "b": "new"}},
+			[]string{"Name", "Age", "Inner", "Inner.Field", "Inner.Keep", "Tags", "Tags.a", "Tags.b"}},
+		{"nested field", map[string]bool{"Inner.Field": true}, S{"new", 2, Inner{"old", "new"}, map[string]string{"a": "new", "b": "new"}},
+			[]string{"Name", "Age", "Inner", "Inner.Field", "Inner.Keep", "Tags", "Tags.a", "Tags.b"}},
+		{"whole struct", map[string]bool{"Inner": true, "Age": true}, S{"new", 1, Inner{"old", "old"}, map[string]string{"a": "new", "b": "new"}},
+			[]string{"Name", "Age", "Inner", "Tags", "Tags.a", "Tags.b"}},
+		{"map entry", map[string]bool{"Tags.b": true}, S{"new", 2, Inner{"new", "new"}, map[string]string{"a": "new"}},
+			[]string{"Name", "Age", "Inner", "Inner.Field", "Inner.Keep", "Tags", "Tags.a", "Tags.b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+
+			to := old()
+
+			err := CopyE(from, &to, When(func(path string, _ reflect.Value) bool {
+				paths = append(paths, path)
+
+				return !tt.reject[path]
+			}))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(to, tt.want) {
+				t.Fatalf("got %+v, want %+v", to, tt.want)
+			}
+
+			sort.Strings(paths)
+			sort.Strings(tt.paths)
+
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Fatalf("paths = %v, want %v", paths, tt.paths)
+			}
+		})
+	}
+}
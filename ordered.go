@@ -0,0 +1,54 @@
+package copy
+
+import (
+	"reflect"
+)
+
+// OrderedMap describes ordered map types, such as those provided by
+// third-party libraries, that are copied like maps into structs and maps.
+// Sources are matched by method shape, so any type with these methods is
+// recognized whatever its key and value types. A type can be checked
+// against it at compile time:
+//
+//	var _ copy.OrderedMap[string, any] = (*orderedmap.Map[string, any])(nil)
+type OrderedMap[K comparable, V any] interface {
+	Keys() []K
+	Get(key K) (V, bool)
+}
+
+func isOrderedMap(reflectType reflect.Type) bool {
+	ptrType := reflect.PointerTo(reflectType)
+
+	keys, ok := ptrType.MethodByName("Keys")
+
+	if !ok || keys.Type.NumIn() != 1 || keys.Type.NumOut() != 1 || keys.Type.Out(0).Kind() != reflect.Slice {
+		return false
+	}
+
+	get, ok := ptrType.MethodByName("Get")
+	keyType := keys.Type.Out(0).Elem()
+
+	return ok && get.Type.NumIn() == 2 && get.Type.In(1) == keyType && get.Type.NumOut() == 2 && get.Type.Out(1).Kind() == reflect.Bool && keyType.Comparable()
+}
+
+func orderedMap(fromValue reflect.Value) (reflect.Value, bool) {
+	if !fromValue.CanInterface() || !isOrderedMap(fromValue.Type()) {
+		return reflect.Value{}, false
+	}
+
+	fromValue = addressable(fromValue)
+
+	keys, _ := methodByName(fromValue, "Keys")
+	get, _ := methodByName(fromValue, "Get")
+
+	result := reflect.MakeMap(reflect.MapOf(keys.Type().Out(0).Elem(), get.Type().Out(0)))
+	list := keys.Call(nil)[0]
+
+	for i := 0; i < list.Len(); i++ {
+		if out := get.Call([]reflect.Value{list.Index(i)}); out[1].Bool() {
+			result.SetMapIndex(list.Index(i), out[0])
+		}
+	}
+
+	return result, true
+}
@@ -0,0 +1,72 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type orderedStub[K comparable, V any] struct {
+	keys []K
+	m    map[K]V
+}
+
+var _ OrderedMap[string, any] = (*orderedStub[string, any])(nil)
+
+func (o *orderedStub[K, V]) Set(key K, value V) {
+	if o.m == nil {
+		o.m = map[K]V{}
+	}
+
+	if _, ok := o.m[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.m[key] = value
+}
+
+func (o *orderedStub[K, V]) Keys() []K {
+	return o.keys
+}
+
+func (o *orderedStub[K, V]) Get(key K) (V, bool) {
+	v, ok := o.m[key]
+
+	return v, ok
+}
+
+func TestOrderedMap(t *testing.T) {
+	type P struct {
+		Name string
+		Age  int
+	}
+
+	var o orderedStub[string, any]
+
+	o.Set("Name", "ann")
+	o.Set("Age", "3")
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want any
+	}{
+		{"pointer into struct", &o, &P{}, &P{Name: "ann", Age: 3}},
+		{"value into struct", o, &P{}, &P{Name: "ann", Age: 3}},
+		{"into map", &o, &map[string]string{}, &map[string]string{"Name": "ann", "Age": "3"}},
+		{"nested field", struct{ In *orderedStub[string, any] }{&o}, &struct{ In P }{}, &struct{ In P }{P{Name: "ann", Age: 3}}},
+		{"not an ordered map", struct{ Keys []string }{[]string{"Name"}}, &P{}, &P{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CopyE(tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %+v, want %+v", tt.to, tt.want)
+			}
+		})
+	}
+}
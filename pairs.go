@@ -0,0 +1,110 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+)
+
+func ToPairs(from any, to any, opts ...Option) error {
+	fromValue := indirectInterface(reflect.ValueOf(from))
+	toValue := reflect.ValueOf(to)
+
+	if fromValue.Kind() != reflect.Struct {
+		return ErrInvalidSource
+	}
+
+	if toValue.Kind() != reflect.Pointer || toValue.IsNil() || toValue.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDestination
+	}
+
+	toValue = toValue.Elem()
+	elemType := toValue.Type().Elem()
+
+	if indirectType(elemType).Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	keyField, ok := fieldByName(indirectType(elemType), "Key")
+
+	if !ok {
+		return ErrInvalidDestination
+	}
+
+	valueField, ok := fieldByName(indirectType(elemType), "Value")
+
+	if !ok {
+		return ErrInvalidDestination
+	}
+
+	c := newCopier(opts)
+	fromType := fromValue.Type()
+	pairs := reflect.MakeSlice(toValue.Type(), 0, fromType.NumField())
+
+	var errs []error
+
+	for i := 0; i < fromType.NumField(); i++ {
+		fromField := fromType.Field(i)
+		fromTag := parseFieldTag(fromField)
+
+		if fromTag.ignore || !fromField.IsExported() || c.only != nil && !c.only[fromField.Name] {
+			continue
+		}
+
+		name := fromTag.name
+
+		if mapped, ok := c.mapping[fromField.Name]; ok {
+			name = mapped
+		}
+
+		fc := c.at(name)
+
+		if c.when != nil && !c.when(fc.path, fromValue.Field(i)) {
+			continue
+		}
+
+		pair := reflect.New(elemType).Elem()
+		allocValue(pair)
+
+		if _, err := copyValue(reflect.ValueOf(name), indirectValue(pair).FieldByIndex(keyField.Index)); err != nil {
+			errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+
+			continue
+		}
+
+		if _, err := fc.copyValue(fromValue.Field(i), indirectValue(pair).FieldByIndex(valueField.Index)); err != nil {
+			errs = append(errs, &FieldError{Field: fromField.Name, Err: err})
+
+			continue
+		}
+
+		pairs = reflect.Append(pairs, pair)
+	}
+
+	toValue.Set(pairs)
+
+	return errors.Join(errs...)
+}
+
+func ToLogFields(from any) []any {
+	fromValue := indirectInterface(reflect.ValueOf(from))
+
+	if fromValue.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fromType := fromValue.Type()
+	fields := make([]any, 0, 2*fromType.NumField())
+
+	for i := 0; i < fromType.NumField(); i++ {
+		fromField := fromType.Field(i)
+		fromTag := parseFieldTag(fromField)
+
+		if fromTag.ignore || !fromField.IsExported() {
+			continue
+		}
+
+		fields = append(fields, fromTag.name, fromValue.Field(i).Interface())
+	}
+
+	return fields
+}
@@ -0,0 +1,86 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToPairs(t *testing.T) {
+	type Pair struct {
+		Key   string
+		Value any
+	}
+
+	type Tagged struct {
+		Name string `copy:"Key"`
+		Text string `copy:"Value"`
+	}
+
+	from := struct {
+		Z      int
+		A      string
+		B      bool `copy:"b"`
+		hidden int
+		I      int `copy:"-"`
+	}{1, "a", true, 0, 3}
+
+	var pairs []Pair
+
+	if err := ToPairs(from, &pairs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Pair{{"Z", 1}, {"A", "a"}, {"b", true}}
+
+	if len(pairs) != len(want) {
+		t.Fatalf("got %v, want %v", pairs, want)
+	}
+
+	for i := range want {
+		if pairs[i] != want[i] {
+			t.Fatalf("pairs[%d] = %v, want %v", i, pairs[i], want[i])
+		}
+	}
+
+	var tagged []*Tagged
+
+	if err := ToPairs(&from, &tagged); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tagged) != 3 || *tagged[0] != (Tagged{Name: "Z", Text: "1"}) || *tagged[2] != (Tagged{Name: "b", Text: "true"}) {
+		t.Fatalf("got %v", tagged)
+	}
+}
+
+func TestToLogFields(t *testing.T) {
+	type S struct {
+		ID     int    `copy:"id"`
+		Secret string `copy:"-"`
+		Name   string
+		hidden int
+		Tags   []string `copy:"tags,omitzero"`
+	}
+
+	from := S{ID: 1, Secret: "s", Name: "n", hidden: 2, Tags: []string{"a"}}
+
+	tests := []struct {
+		name string
+		from any
+		want []any
+	}{
+		{"declaration order", from, []any{"id", 1, "Name", "n", "tags", []string{"a"}}},
+		{"pointer", &from, []any{"id", 1, "Name", "n", "tags", []string{"a"}}},
+		{"empty struct", struct{}{}, []any{}},
+		{"not a struct", 3, nil},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToLogFields(tt.from); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package copy
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+func (c *copier) copyParallel(fromValue reflect.Value, toValue reflect.Value) []error {
+	n := fromValue.Len()
+	elems := reflect.MakeSlice(toValue.Type(), n, n)
+	copied := make([]bool, n)
+	elemErrs := make([]error, n)
+
+	workers := ParallelWorkers
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+
+		if end > n {
+			end = n
+		}
+
+		wg.Add(1)
+
+		go func(start int, end int) {
+			defer wg.Done()
+
+			for i := start; i < end; i++ {
+				copied[i], elemErrs[i] = c.at(strconv.Itoa(i)).copyValue(fromValue.Index(i), elems.Index(i))
+
+				if !copied[i] && elemErrs[i] == nil && !SkipNonImplementing {
+					elemErrs[i] = checkImplements(fromValue.Index(i), elems.Type().Elem())
+				}
+			}
+		}(start, end)
+	}
+
+	wg.Wait()
+
+	var errs []error
+
+	j := 0
+
+	for i := 0; i < n; i++ {
+		if elemErrs[i] != nil {
+			errs = append(errs, &FieldError{Field: "[" + strconv.Itoa(i) + "]", Err: elemErrs[i]})
+		}
+
+		if copied[i] {
+			if i != j {
+				elems.Index(j).Set(elems.Index(i))
+			}
+
+			j++
+		}
+	}
+
+	toValue.Set(reflect.AppendSlice(toValue, elems.Slice(0, j)))
+
+	return errs
+}
@@ -0,0 +1,155 @@
+package copy
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type parallelSource struct {
+	N string
+}
+
+type parallelDest struct {
+	N int
+}
+
+func parallelSources(n int) []parallelSource {
+	from := make([]parallelSource, n)
+
+	for i := range from {
+		from[i].N = strconv.Itoa(i)
+	}
+
+	return from
+}
+
+func TestParallelSliceCopy(t *testing.T) {
+	from := parallelSources(1000)
+
+	tests := []struct {
+		name      string
+		threshold int
+		workers   int
+		prefix    []parallelDest
+	}{
+		{"serial", 0, 0, nil},
+		{"below threshold", 5000, 4, nil},
+		{"default workers", 10, 0, nil},
+		{"four workers", 10, 4, nil},
+		{"more workers than elements", 10, 2000, nil},
+		{"appends after existing", 10, 3, []parallelDest{{-1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &ParallelThreshold, tt.threshold)
+			setVar(t, &ParallelWorkers, tt.workers)
+
+			to := tt.prefix
+
+			if err := CopyE(from, &to); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(to) != len(tt.prefix)+len(from) {
+				t.Fatalf("len = %d, want %d", len(to), len(tt.prefix)+len(from))
+			}
+
+			for i, d := range to[len(tt.prefix):] {
+				if d.N != i {
+					t.Fatalf("[%d] = %+v, want %d", i, d, i)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelSliceCopyErrors(t *testing.T) {
+	setVar(t, &ParallelThreshold, 10)
+	setVar(t, &ParallelWorkers, 4)
+	setVar(t, &CheckedConversions, true)
+
+	var to []int8
+
+	err := CopyE([]int{1, 2, 300, 4, 5, 6, 7, 8, 9, 10, 11, 1000}, &to)
+
+	var fieldErr *FieldError
+
+	if !errors.Is(err, ErrOverflow) || !errors.As(err, &fieldErr) || fieldErr.Field != "[2]" {
+		t.Fatalf("err = %v, want an ErrOverflow FieldError for [2]", err)
+	}
+
+	if len(to) != 10 || to[2] != 4 || to[9] != 11 {
+		t.Fatalf("got %v", to)
+	}
+}
+
+// run with -race: parallel copies share the source and write to disjoint
+// destination indices.
+func TestParallelSliceCopyConcurrent(t *testing.T) {
+	setVar(t, &ParallelThreshold, 10)
+	setVar(t, &ParallelWorkers, 8)
+
+	from := parallelSources(500)
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, 8)
+
+	for g := 0; g < len(errs); g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			var to []parallelDest
+
+			if err := CopyE(from, &to); err != nil {
+				errs[g] = err
+
+				return
+			}
+
+			for i, d := range to {
+				if d.N != i {
+					errs[g] = errors.New("element " + strconv.Itoa(i) + " out of order")
+
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkParallelSliceCopy(b *testing.B) {
+	from := parallelSources(10000)
+
+	tests := []struct {
+		name      string
+		threshold int
+	}{
+		{"serial", 0},
+		{"parallel", 1000},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			ParallelThreshold = tt.threshold
+			defer func() { ParallelThreshold = 0 }()
+
+			for i := 0; i < b.N; i++ {
+				var to []parallelDest
+
+				Copy(from, &to)
+			}
+		})
+	}
+}
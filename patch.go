@@ -0,0 +1,105 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+var ErrUnknownField = errors.New("copy: unknown field")
+
+func Patch(updates map[string]any, to any) ([]string, error) {
+	toValue := reflect.ValueOf(to)
+
+	if !toValue.IsValid() || toValue.Kind() != reflect.Pointer || toValue.IsNil() {
+		return nil, ErrInvalidDestination
+	}
+
+	toValue = indirectValue(toValue)
+
+	if toValue.Kind() != reflect.Struct {
+		return nil, ErrInvalidDestination
+	}
+
+	toType := toValue.Type()
+
+	keys := make([]string, 0, len(updates))
+
+	for k := range updates {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var errs []error
+
+	for _, k := range keys {
+		if _, ok := fieldByKey(toType, k); !ok {
+			errs = append(errs, &FieldError{Field: k, Err: ErrUnknownField})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var applied []string
+
+	for _, k := range keys {
+		toField, _ := fieldByKey(toType, k)
+		toFieldValue, ok := fieldByIndex(toValue, toField.Index)
+
+		if !ok || !toFieldValue.CanSet() {
+			continue
+		}
+
+		ok, err := copyValue(reflect.ValueOf(updates[k]), toFieldValue)
+
+		if err != nil {
+			errs = append(errs, &FieldError{Field: k, Err: err})
+		}
+
+		if ok {
+			applied = append(applied, toField.Name)
+		}
+	}
+
+	return applied, errors.Join(errs...)
+}
+
+func CopyDelta(baseline any, updated any, to any) error {
+	baselineValue := indirectValue(reflect.ValueOf(baseline))
+	updatedValue := indirectValue(reflect.ValueOf(updated))
+	toValue := reflect.ValueOf(to)
+
+	if !toValue.IsValid() || toValue.Kind() != reflect.Pointer || toValue.IsNil() {
+		return ErrInvalidDestination
+	}
+
+	if updatedValue.Kind() != reflect.Struct {
+		return ErrInvalidSource
+	}
+
+	c := newCopier(nil)
+	c.only = make(map[string]bool)
+
+	updatedType := updatedValue.Type()
+
+	for i := 0; i < updatedType.NumField(); i++ {
+		field := updatedType.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if baselineValue.Kind() == reflect.Struct {
+			if v := baselineValue.FieldByName(field.Name); v.IsValid() && reflect.DeepEqual(v.Interface(), updatedValue.Field(i).Interface()) {
+				continue
+			}
+		}
+
+		c.only[field.Name] = true
+	}
+
+	return c.copyValues(updatedValue, toValue)
+}
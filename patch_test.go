@@ -0,0 +1,130 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPatch(t *testing.T) {
+	type User struct {
+		Name  string
+		Age   int
+		Email string `copy:"email"`
+	}
+
+	tests := []struct {
+		name        string
+		updates     map[string]any
+		want        User
+		wantApplied []string
+		wantErr     error
+	}{
+		{
+			name:        "applies only given fields",
+			updates:     map[string]any{"Age": "31", "email": "b@c"},
+			want:        User{Name: "ann", Age: 31, Email: "b@c"},
+			wantApplied: []string{"Age", "Email"},
+		},
+		{
+			name:    "unknown key applies nothing",
+			updates: map[string]any{"Age": 40, "Nope": 1},
+			want:    User{Name: "ann", Age: 30, Email: "a@b"},
+			wantErr: ErrUnknownField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := User{Name: "ann", Age: 30, Email: "a@b"}
+
+			applied, err := Patch(tt.updates, &u)
+
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if u != tt.want {
+				t.Fatalf("got %+v, want %+v", u, tt.want)
+			}
+
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Fatalf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+		})
+	}
+}
+
+func TestPatchPromotedFields(t *testing.T) {
+	type Base struct {
+		ID   int
+		Note string
+	}
+
+	type User struct {
+		*Base
+		Name string
+	}
+
+	tests := []struct {
+		name    string
+		to      User
+		updates map[string]any
+		want    Base
+	}{
+		{"nil embedded pointer", User{}, map[string]any{"ID": "7"}, Base{ID: 7}},
+		{"existing embedded pointer", User{Base: &Base{ID: 1, Note: "keep"}}, map[string]any{"ID": 2}, Base{ID: 2, Note: "keep"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.to
+
+			if _, err := Patch(tt.updates, &u); err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Base == nil || *u.Base != tt.want {
+				t.Fatalf("got %+v, want %+v", u.Base, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyDelta(t *testing.T) {
+	type Doc struct {
+		Title string
+		Body  string
+		Tags  []string
+	}
+
+	baseline := Doc{Title: "a", Body: "b", Tags: []string{"x"}}
+
+	tests := []struct {
+		name    string
+		updated Doc
+		want    Doc
+	}{
+		{"one field changed", Doc{Title: "a2", Body: "b", Tags: []string{"x"}}, Doc{Title: "a2", Body: "server", Tags: []string{"y"}}},
+		{"slice changed", Doc{Title: "a", Body: "b", Tags: []string{"x", "z"}}, Doc{Title: "t", Body: "server", Tags: []string{"x", "z"}}},
+		{"nothing changed", baseline, Doc{Title: "t", Body: "server", Tags: []string{"y"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Doc{Title: "t", Body: "server", Tags: []string{"y"}}
+
+			if err := CopyDelta(baseline, tt.updated, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(d, tt.want) {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+
+	if err := CopyDelta(baseline, 1, &Doc{}); err != ErrInvalidSource {
+		t.Fatalf("err = %v, want ErrInvalidSource", err)
+	}
+}
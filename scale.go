@@ -0,0 +1,124 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func copyScale(fromValue reflect.Value, toValue reflect.Value, scale string) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	n, err := strconv.Atoi(scale)
+
+	if !fromValue.IsValid() || !toValue.IsValid() || err != nil || n < 0 {
+		return false, nil
+	}
+
+	if fromValue.Kind() == reflect.String && (isInt(toValue.Kind()) || isUint(toValue.Kind())) {
+		v, ok, err := parseScaled(fromValue.String(), n)
+
+		if !ok || err != nil {
+			return false, err
+		}
+
+		if isInt(toValue.Kind()) && toValue.OverflowInt(v) || isUint(toValue.Kind()) && (v < 0 || toValue.OverflowUint(uint64(v))) {
+			return false, ErrOverflow
+		}
+
+		if isInt(toValue.Kind()) {
+			toValue.SetInt(v)
+		} else {
+			toValue.SetUint(uint64(v))
+		}
+
+		return true, nil
+	}
+
+	if toValue.Kind() == reflect.String && (isInt(fromValue.Kind()) || isUint(fromValue.Kind())) {
+		var digits string
+		var negative bool
+
+		if isInt(fromValue.Kind()) {
+			digits = strconv.FormatInt(fromValue.Int(), 10)
+			digits, negative = strings.CutPrefix(digits, "-")
+		} else {
+			digits = strconv.FormatUint(fromValue.Uint(), 10)
+		}
+
+		toValue.Set(reflect.ValueOf(formatScaled(digits, negative, n)).Convert(toValue.Type()))
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func parseScaled(s string, scale int) (int64, bool, error) {
+	s = strings.TrimSpace(s)
+
+	sign := ""
+
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+
+	if whole == "" && frac == "" || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, false, nil
+	}
+
+	roundUp := false
+
+	if len(frac) > scale {
+		roundUp = frac[scale] >= '5'
+		frac = frac[:scale]
+	}
+
+	frac += strings.Repeat("0", scale-len(frac))
+
+	v, err := strconv.ParseInt(sign+whole+frac, 10, 64)
+
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, false, fmt.Errorf("%w: %s%s does not fit in int64 with scale %d", ErrOverflow, sign, s, scale)
+	}
+
+	if err != nil {
+		return 0, false, nil
+	}
+
+	if roundUp {
+		if sign == "-" && v == math.MinInt64 || sign != "-" && v == math.MaxInt64 {
+			return 0, false, fmt.Errorf("%w: rounding %s%s overflows int64", ErrOverflow, sign, s)
+		}
+
+		if sign == "-" {
+			v--
+		} else {
+			v++
+		}
+	}
+
+	return v, true, nil
+}
+
+func formatScaled(digits string, negative bool, scale int) string {
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	if scale > 0 {
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+
+	if negative {
+		digits = "-" + digits
+	}
+
+	return digits
+}
@@ -0,0 +1,82 @@
+package copy
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestScaleTag(t *testing.T) {
+	type Price struct {
+		Amount int64 `copy:",scale=2"`
+	}
+
+	type Text struct {
+		Amount string
+	}
+
+	parse := []struct {
+		from    string
+		want    int64
+		wantErr error
+	}{
+		{"12.34", 1234, nil},
+		{"12.345", 1235, nil},
+		{"-12.345", -1235, nil},
+		{"-0.5", -50, nil},
+		{"7", 700, nil},
+		{"0.07", 7, nil},
+		{".1", 10, nil},
+		{"92233720368547758.07", math.MaxInt64, nil},
+		{"-92233720368547758.08", math.MinInt64, nil},
+		{"92233720368547758.075", 0, ErrOverflow},
+		{"-92233720368547758.085", 0, ErrOverflow},
+		{"92233720368547759", 0, ErrOverflow},
+	}
+
+	for _, tt := range parse {
+		t.Run("parse "+tt.from, func(t *testing.T) {
+			var p Price
+
+			if err := CopyE(Text{Amount: tt.from}, &p); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if p.Amount != tt.want {
+				t.Fatalf("got %d, want %d", p.Amount, tt.want)
+			}
+		})
+	}
+
+	format := []struct {
+		from int64
+		want string
+	}{
+		{1234, "12.34"},
+		{7, "0.07"},
+		{-50, "-0.50"},
+		{0, "0.00"},
+	}
+
+	for _, tt := range format {
+		t.Run("format "+tt.want, func(t *testing.T) {
+			var s Text
+
+			if err := CopyE(Price{Amount: tt.from}, &s); err != nil {
+				t.Fatal(err)
+			}
+
+			if s.Amount != tt.want {
+				t.Fatalf("got %q, want %q", s.Amount, tt.want)
+			}
+		})
+	}
+
+	var p Price
+
+	Copy(map[string]any{"Amount": "1.5"}, &p)
+
+	if p.Amount != 150 {
+		t.Fatalf("map value = %d", p.Amount)
+	}
+}
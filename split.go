@@ -0,0 +1,68 @@
+package copy
+
+import (
+	"reflect"
+	"strings"
+)
+
+func copySplit(fromValue reflect.Value, toValue reflect.Value, sep string) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !toValue.IsValid() || sep == "" {
+		return false, nil
+	}
+
+	fromType := fromValue.Type()
+	toType := toValue.Type()
+
+	if (fromType.Kind() == reflect.Slice || fromType.Kind() == reflect.Array) && !isBytes(fromType) && toType.Kind() == reflect.String {
+		items := make([]string, 0, fromValue.Len())
+
+		for i := 0; i < fromValue.Len(); i++ {
+			var item string
+
+			ok, err := copyValue(fromValue.Index(i), reflect.ValueOf(&item))
+
+			if err != nil {
+				return false, err
+			}
+
+			if ok && item != "" {
+				items = append(items, item)
+			}
+		}
+
+		toValue.Set(reflect.ValueOf(strings.Join(items, sep)).Convert(toType))
+
+		return true, nil
+	}
+
+	if fromType.Kind() == reflect.String && toType.Kind() == reflect.Slice && !isBytes(toType) {
+		v := reflect.MakeSlice(toType, 0, 0)
+
+		for _, item := range strings.Split(fromValue.String(), sep) {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+
+			e := reflect.New(toType.Elem()).Elem()
+
+			ok, err := copyValue(reflect.ValueOf(item), e)
+
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				v = reflect.Append(v, e)
+			}
+		}
+
+		toValue.Set(v)
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,76 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitTag(t *testing.T) {
+	type A struct {
+		Tags []string
+		IDs  string
+	}
+
+	type B struct {
+		Tags string `copy:",split=;"`
+		IDs  []int  `copy:",split=,"`
+	}
+
+	var b B
+
+	if err := CopyE(A{Tags: []string{"a", "", "b"}, IDs: " 1, 2,,3 "}, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Tags != "a;b" || !reflect.DeepEqual(b.IDs, []int{1, 2, 3}) {
+		t.Fatalf("got %+v", b)
+	}
+
+	var a A
+
+	if err := CopyE(B{Tags: "x; y", IDs: []int{4, 5}}, &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a.Tags, []string{"x", "y"}) || a.IDs != "4,5" {
+		t.Fatalf("got %+v", a)
+	}
+}
+
+func TestSliceSeparator(t *testing.T) {
+	tests := []struct {
+		sep  string
+		from []int
+		want string
+	}{
+		{"|", []int{1, 2}, "1|2"},
+		{", ", []int{3}, "3"},
+		{"-", []int{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sep, func(t *testing.T) {
+			setVar(t, &SliceSeparator, tt.sep)
+
+			var s string
+
+			if err := CopyE(tt.from, &s); err != nil {
+				t.Fatal(err)
+			}
+
+			if s != tt.want {
+				t.Fatalf("joined %q, want %q", s, tt.want)
+			}
+
+			var back []int
+
+			if err := CopyE(s, &back); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(back) != len(tt.from) || len(back) > 0 && !reflect.DeepEqual(back, tt.from) {
+				t.Fatalf("split %v, want %v", back, tt.from)
+			}
+		})
+	}
+}
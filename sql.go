@@ -0,0 +1,96 @@
+package copy
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"time"
+)
+
+var (
+	nullTimeType = reflect.TypeOf(sql.NullTime{})
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+func copySQL(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	fromValue = indirectInterface(fromValue)
+	toValue = indirectValue(toValue)
+
+	if !fromValue.IsValid() || !toValue.IsValid() || !fromValue.CanInterface() {
+		return false, nil
+	}
+
+	if ok, err := copyNullTime(fromValue, toValue); ok || err != nil {
+		return ok, err
+	}
+
+	if toValue.CanAddr() {
+		if scanner, ok := toValue.Addr().Interface().(sql.Scanner); ok {
+			v, err := driver.DefaultParameterConverter.ConvertValue(fromValue.Interface())
+
+			if err != nil {
+				return false, nil
+			}
+
+			if err := scanner.Scan(v); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	if valuer, ok := fromValue.Interface().(driver.Valuer); ok && !isComposite(toValue.Kind()) {
+		v, err := valuer.Value()
+
+		if err != nil {
+			return false, err
+		}
+
+		if v == nil {
+			toValue.Set(reflect.Zero(toValue.Type()))
+
+			return true, nil
+		}
+
+		return copyValue(reflect.ValueOf(v), toValue)
+	}
+
+	return false, nil
+}
+
+func copyNullTime(fromValue reflect.Value, toValue reflect.Value) (bool, error) {
+	if fromValue.Type() == nullTimeType && (toValue.Type() == timeType || toValue.Kind() == reflect.String) {
+		if !fromValue.Field(1).Bool() {
+			toValue.Set(reflect.Zero(toValue.Type()))
+
+			return true, nil
+		}
+
+		return copyValue(fromValue.Field(0), toValue)
+	}
+
+	if toValue.Type() == nullTimeType && fromValue.Kind() == reflect.String && toValue.CanSet() {
+		if fromValue.String() == "" {
+			toValue.Set(reflect.Zero(nullTimeType))
+
+			return true, nil
+		}
+
+		if err := strictTimeZone(); err != nil {
+			return false, err
+		}
+
+		t, ok := parseTime(fromValue.String())
+
+		if !ok {
+			return false, nil
+		}
+
+		toValue.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+
+		return true, nil
+	}
+
+	return false, nil
+}
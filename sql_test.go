@@ -0,0 +1,137 @@
+package copy
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type upperText struct {
+	V string
+}
+
+func (u *upperText) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		u.V = strings.ToUpper(s)
+	case int64:
+		u.V = fmt.Sprint(s)
+	default:
+		return fmt.Errorf("cannot scan %T", src)
+	}
+
+	return nil
+}
+
+func (u upperText) Value() (driver.Value, error) {
+	return strings.ToLower(u.V), nil
+}
+
+func TestScannerAndValuer(t *testing.T) {
+	var d struct {
+		A, B upperText
+		N    sql.NullString
+		I    sql.NullInt64
+	}
+
+	from := struct {
+		A string
+		B int
+		N string
+		I string
+	}{"abc", 5, "n", "7"}
+
+	if err := CopyE(from, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.A.V != "ABC" || d.B.V != "5" || d.N != (sql.NullString{String: "n", Valid: true}) || d.I != (sql.NullInt64{Int64: 7, Valid: true}) {
+		t.Fatalf("scanned %+v", d)
+	}
+
+	var o struct {
+		A string
+		N string
+		I int
+	}
+
+	back := struct {
+		A upperText
+		N sql.NullString
+		I sql.NullInt64
+	}{upperText{"XY"}, sql.NullString{String: "q", Valid: true}, sql.NullInt64{Int64: 3, Valid: true}}
+
+	if err := CopyE(back, &o); err != nil {
+		t.Fatal(err)
+	}
+
+	if o.A != "xy" || o.N != "q" || o.I != 3 {
+		t.Fatalf("valued %+v", o)
+	}
+
+	if err := CopyE(struct{ A float64 }{1.5}, &struct{ A upperText }{}); err == nil {
+		t.Fatal("expected a Scan error")
+	}
+}
+
+func TestNullTime(t *testing.T) {
+	setVar(t, &TimeZone, "UTC")
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	valid := sql.NullTime{Time: at, Valid: true}
+
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name    string
+		from    any
+		to      any
+		want    any
+		wantErr bool
+	}{
+		{"valid into time", valid, new(time.Time), &at, false},
+		{"null into time clears", sql.NullTime{}, &at, new(time.Time), false},
+		{"valid into string", valid, new(string), str("2024-01-02 03:04:05"), false},
+		{"null into string clears", sql.NullTime{}, str("old"), new(string), false},
+		{"time into null time", at, new(sql.NullTime), &valid, false},
+		{"string into null time", "2024-01-02 03:04:05", new(sql.NullTime), &valid, false},
+		{"empty string into null time", "", &sql.NullTime{Time: at, Valid: true}, new(sql.NullTime), false},
+		{"zero time scans as valid", time.Time{}, &sql.NullTime{Time: at, Valid: true}, &sql.NullTime{Valid: true}, false},
+		{"unparsable string", "bad", new(sql.NullTime), new(sql.NullTime), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CopyE(tt.from, tt.to)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %v, want %v", reflect.ValueOf(tt.to).Elem(), reflect.ValueOf(tt.want).Elem())
+			}
+		})
+	}
+}
+
+func TestNullTimeLayout(t *testing.T) {
+	setVar(t, &DateTimeLayout, time.RFC3339)
+	setVar(t, &TimeZone, "America/New_York")
+
+	var s string
+
+	if err := CopyE(sql.NullTime{Time: time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC), Valid: true}, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s != "2024-01-02T10:00:00-05:00" {
+		t.Fatalf("got %q", s)
+	}
+}
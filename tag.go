@@ -0,0 +1,211 @@
+package copy
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type fieldTag struct {
+	name    string
+	ignore  bool
+	options []string
+}
+
+func parseFieldTag(field reflect.StructField) fieldTag {
+	return parseTag(field, TagKey)
+}
+
+func parseTag(field reflect.StructField, key string) fieldTag {
+	tag := fieldTag{name: field.Name}
+
+	value, ok := field.Tag.Lookup(key)
+
+	if !ok {
+		return tag
+	}
+
+	if value == "-" {
+		tag.ignore = true
+
+		return tag
+	}
+
+	name, options, _ := strings.Cut(value, ",")
+
+	if name != "" {
+		tag.name = name
+	}
+
+	if options != "" {
+		for _, option := range strings.Split(options, ",") {
+			if option == "" && len(tag.options) > 0 && strings.HasSuffix(tag.options[len(tag.options)-1], "=") {
+				tag.options[len(tag.options)-1] += ","
+
+				continue
+			}
+
+			tag.options = append(tag.options, option)
+		}
+	}
+
+	return tag
+}
+
+func (t fieldTag) hasOption(name string) bool {
+	for _, option := range t.options {
+		if option == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type tagIndexKey struct {
+	structType reflect.Type
+	key        string
+}
+
+var tagIndexes sync.Map
+
+func tagIndex(structType reflect.Type, key string) map[string]reflect.StructField {
+	if v, ok := tagIndexes.Load(tagIndexKey{structType, key}); ok {
+		return v.(map[string]reflect.StructField)
+	}
+
+	index := make(map[string]reflect.StructField, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if tag := parseTag(field, key); !tag.ignore {
+			if _, ok := index[tag.name]; !ok {
+				index[tag.name] = field
+			}
+		}
+	}
+
+	tagIndexes.Store(tagIndexKey{structType, key}, index)
+
+	return index
+}
+
+func (t fieldTag) option(key string) (string, bool) {
+	for _, option := range t.options {
+		if v, ok := strings.CutPrefix(option, key+"="); ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func fieldByName(structType reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := tagIndex(structType, TagKey)[name]; ok {
+		return field, true
+	}
+
+	if field, ok := structType.FieldByName(name); ok && !parseFieldTag(field).ignore {
+		return field, true
+	}
+
+	return reflect.StructField{}, false
+}
+
+func fieldByKey(structType reflect.Type, key string) (reflect.StructField, bool) {
+	if field, ok := fieldByName(structType, key); ok {
+		return field, true
+	}
+
+	if JSONTagFallback {
+		if field, ok := tagIndex(structType, "json")[key]; ok && !parseFieldTag(field).ignore {
+			return field, true
+		}
+	}
+
+	if EnvKeyMatch {
+		if field, ok := envIndex(structType)[key]; ok {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func fieldByMapKey(structType reflect.Type, keyValue reflect.Value, key string) (reflect.StructField, bool) {
+	if keyValue = indirectInterface(keyValue); IndexedMapKeys && (isInt(keyValue.Kind()) || isUint(keyValue.Kind())) {
+		var i int
+
+		if isInt(keyValue.Kind()) {
+			i = int(keyValue.Int())
+		} else {
+			i = int(keyValue.Uint())
+		}
+
+		if i < 0 || i >= structType.NumField() || parseFieldTag(structType.Field(i)).ignore {
+			return reflect.StructField{}, false
+		}
+
+		return structType.Field(i), true
+	}
+
+	return fieldByKey(structType, key)
+}
+
+var envIndexes sync.Map
+
+func envIndex(structType reflect.Type) map[string]reflect.StructField {
+	if v, ok := envIndexes.Load(tagIndexKey{structType, TagKey}); ok {
+		return v.(map[string]reflect.StructField)
+	}
+
+	index := make(map[string]reflect.StructField, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if parseFieldTag(field).ignore {
+			continue
+		}
+
+		if name := envName(field.Name); name != "" {
+			if _, ok := index[name]; !ok {
+				index[name] = field
+			}
+		}
+	}
+
+	envIndexes.Store(tagIndexKey{structType, TagKey}, index)
+
+	return index
+}
+
+func envName(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
+
+func inlineField(structType reflect.Type) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if field.IsExported() && field.Type.Kind() == reflect.Map && field.Type.Key().Kind() == reflect.String && parseFieldTag(field).hasOption("inline") {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,555 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTagKey(t *testing.T) {
+	setVar(t, &TagKey, "json")
+
+	type D struct {
+		UserID int    `json:"user_id"`
+		Name   string `json:"name"`
+		Secret string `json:"-"`
+	}
+
+	var d D
+
+	if err := CopyE(map[string]any{"user_id": 7, "name": "ann", "Secret": "x"}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d != (D{UserID: 7, Name: "ann"}) {
+		t.Fatalf("got %+v", d)
+	}
+
+	m := map[string]any{}
+
+	if err := CopyE(d, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m) != 2 || m["user_id"] != 7 || m["name"] != "ann" {
+		t.Fatalf("got %v", m)
+	}
+}
+
+func TestJSONTagFallback(t *testing.T) {
+	type D struct {
+		UserID int    `json:"user_id,omitempty"`
+		Email  string `json:",omitempty"`
+		Nick   string `copy:"nick" json:"nickname"`
+		Skip   string `json:"-"`
+	}
+
+	from := map[string]any{"user_id": 7, "Email": "a@b", "nick": "n", "Skip": "x", "-": "y"}
+
+	tests := []struct {
+		name     string
+		fallback bool
+		want     D
+	}{
+		{"disabled", false, D{Email: "a@b", Nick: "n", Skip: "x"}},
+		{"enabled", true, D{UserID: 7, Email: "a@b", Nick: "n", Skip: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &JSONTagFallback, tt.fallback)
+
+			var d D
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapKeysUseDestinationTags(t *testing.T) {
+	type D struct {
+		UserName string `copy:"user_name"`
+		Age      int
+	}
+
+	var d D
+
+	if err := CopyE(map[string]any{"user_name": "ann", "Age": "3"}, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d != (D{UserName: "ann", Age: 3}) {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+func TestOmitZeroTag(t *testing.T) {
+	type D struct {
+		Name  string `copy:",omitzero"`
+		Count int    `copy:"count,omitzero"`
+		Note  string
+	}
+
+	tests := []struct {
+		name string
+		from any
+		want D
+	}{
+		{"struct zero values", struct {
+			Name  string
+			Count int `copy:"count"`
+			Note  string
+		}{}, D{Name: "keep", Count: 9}},
+		{"struct non-zero values", struct {
+			Name  string
+			Count int `copy:"count"`
+			Note  string
+		}{Name: "new", Count: 1, Note: "n"}, D{Name: "new", Count: 1, Note: "n"}},
+		{"map zero values", map[string]any{"Name": "", "count": 0, "Note": ""}, D{Name: "keep", Count: 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := D{Name: "keep", Count: 9, Note: "old"}
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvKeyMatch(t *testing.T) {
+	type Conf struct {
+		Port     int
+		DBHost   string
+		Debug    bool
+		UserID   uint
+		HTTPAddr string
+		Start    time.Time
+		Rate     float64
+	}
+
+	env := map[string]string{
+		"PORT":      "80",
+		"DB_HOST":   "h",
+		"DEBUG":     "true",
+		"USER_ID":   "4",
+		"HTTP_ADDR": "a",
+		"START":     "2024-01-02 03:04:05",
+		"RATE":      "1.5",
+	}
+
+	tests := []struct {
+		name  string
+		match bool
+		want  Conf
+	}{
+		{"disabled", false, Conf{}},
+		{"enabled", true, Conf{Port: 80, DBHost: "h", Debug: true, UserID: 4, HTTPAddr: "a", Rate: 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &EnvKeyMatch, tt.match)
+
+			var c Conf
+
+			if err := CopyE(env, &c); err != nil {
+				t.Fatal(err)
+			}
+
+			start := c.Start
+			c.Start = time.Time{}
+
+			if c != tt.want || tt.match && start.Year() != 2024 {
+				t.Fatalf("got %+v (Start %v), want %+v", c, start, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvKeyMatchTagKey(t *testing.T) {
+	setVar(t, &EnvKeyMatch, true)
+
+	type Conf struct {
+		Port   int    `copy:"-"`
+		DBHost string `env:"-"`
+	}
+
+	env := map[string]string{"PORT": "80", "DB_HOST": "h"}
+
+	tests := []struct {
+		tagKey string
+		want   Conf
+	}{
+		{"copy", Conf{DBHost: "h"}},
+		{"env", Conf{Port: 80}},
+		{"copy", Conf{DBHost: "h"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tagKey, func(t *testing.T) {
+			setVar(t, &TagKey, tt.tagKey)
+
+			var c Conf
+
+			if err := CopyE(env, &c); err != nil {
+				t.Fatal(err)
+			}
+
+			if c != tt.want {
+				t.Fatalf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexedMapKeys(t *testing.T) {
+	type P struct {
+		Name string
+		Age  int
+		Skip string `copy:"-"`
+	}
+
+	tests := []struct {
+		name    string
+		indexed bool
+		from    any
+		want    P
+	}{
+		{"disabled", false, map[int]any{0: "n", 1: "3"}, P{}},
+		{"by position", true, map[int]any{0: "n", 1: "3", 2: "x", 5: "y", -1: "z"}, P{Name: "n", Age: 3}},
+		{"mixed keys", true, map[any]any{"Name": "m", uint8(1): 4}, P{Name: "m", Age: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &IndexedMapKeys, tt.indexed)
+
+			var p P
+
+			if err := CopyE(tt.from, &p); err != nil {
+				t.Fatal(err)
+			}
+
+			if p != tt.want {
+				t.Fatalf("got %+v, want %+v", p, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredTag(t *testing.T) {
+	type Inner struct {
+		Code string `copy:",required"`
+	}
+
+	type D struct {
+		Name  string `copy:",required"`
+		Email string `copy:",required"`
+		In    Inner
+	}
+
+	tests := []struct {
+		name string
+		from any
+		want string
+	}{
+		{"one satisfied one missing", struct{ Name, Nick string }{"n", "x"}, "Email: copy: required field not set\nIn: Code: copy: required field not set"},
+		{"nested missing", struct {
+			Name, Email string
+			In          struct{ X int }
+		}{"n", "e", struct{ X int }{1}}, "In: Code: copy: required field not set"},
+		{"all missing", struct{ Nick string }{"x"}, "Name: copy: required field not set\nEmail: copy: required field not set\nIn: Code: copy: required field not set"},
+		{"nested not in source", struct{ Name, Email string }{"n", "e"}, "In: Code: copy: required field not set"},
+		{"nested from map without it", map[string]any{"Name": "n", "Email": "e"}, "In: Code: copy: required field not set"},
+		{"zero value", map[string]any{"Name": "n", "Email": "", "In": map[string]any{"Code": "c"}}, "Email: copy: required field not set"},
+		{"all set", map[string]any{"Name": "n", "Email": "e", "In": map[string]any{"Code": "c"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d D
+
+			err := CopyE(tt.from, &d)
+
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrRequired) || err.Error() != tt.want {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredNested(t *testing.T) {
+	type In struct {
+		N int `copy:",required"`
+	}
+
+	type D struct {
+		Inner In
+	}
+
+	type Deep struct {
+		D D
+	}
+
+	tests := []struct {
+		name string
+		from any
+		to   any
+		want string
+	}{
+		{"missing from source", struct{ Z int }{}, &D{}, "Inner: N: copy: required field not set"},
+		{"same type", D{}, &D{}, "Inner: N: copy: required field not set"},
+		{"assigned whole", struct{ Inner In }{}, &D{}, "Inner: N: copy: required field not set"},
+		{"two levels", struct{ Z int }{}, &Deep{}, "D: Inner: N: copy: required field not set"},
+		{"set in destination", struct{ Z int }{}, &D{Inner: In{N: 1}}, ""},
+		{"copied", D{Inner: In{N: 2}}, &D{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CopyE(tt.from, tt.to)
+
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrRequired) || err.Error() != tt.want {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagToTag(t *testing.T) {
+	type D struct {
+		DstName string `copy:"uid"`
+		Mail    string `json:"email"`
+		Other   string `json:"other"`
+		Skip    string `copy:"-" json:"skip"`
+	}
+
+	from := struct {
+		SrcName string `copy:"uid"`
+		Email   string `json:"email"`
+		Other   string `json:"x"`
+		Hidden  string `json:"skip"`
+	}{"u", "e", "o", "h"}
+
+	tests := []struct {
+		name     string
+		tagToTag bool
+		want     D
+	}{
+		{"disabled", false, D{DstName: "u", Other: "o"}},
+		{"enabled", true, D{DstName: "u", Mail: "e", Other: "o"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &TagToTag, tt.tagToTag)
+
+			var d D
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d != tt.want {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	type S struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name,omitempty"`
+		Note  string `json:",omitempty"`
+		Skip  string `json:"-"`
+		Plain int
+	}
+
+	tests := []struct {
+		name string
+		json bool
+		from S
+		want map[string]any
+	}{
+		{"disabled", false, S{ID: 1, Skip: "x"}, map[string]any{"ID": 1, "Name": "", "Note": "", "Skip": "x", "Plain": 0}},
+		{"omitempty skips zero", true, S{ID: 1, Note: "n", Skip: "x", Plain: 2}, map[string]any{"id": 1, "Note": "n", "Plain": 2}},
+		{"omitempty keeps set", true, S{Name: "ann"}, map[string]any{"id": 0, "name": "ann", "Plain": 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &JSONMapKeys, tt.json)
+
+			m := map[string]any{}
+
+			if err := CopyE(tt.from, &m); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(m, tt.want) {
+				t.Fatalf("got %v, want %v", m, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineExtras(t *testing.T) {
+	type D struct {
+		Name   string
+		Extras map[string]any `copy:",inline"`
+	}
+
+	tests := []struct {
+		name string
+		from any
+		to   D
+		want D
+	}{
+		{"unmatched map keys", map[string]any{"Name": "x", "age": 3, "tags": "a"}, D{}, D{"x", map[string]any{"age": 3, "tags": "a"}}},
+		{"unmatched struct fields", struct {
+			Name   string
+			Age    int
+			Nick   string `copy:"nick"`
+			hidden int
+			Skip   string `copy:"-"`
+		}{Name: "y", Age: 4, Nick: "n", hidden: 1, Skip: "s"}, D{}, D{"y", map[string]any{"Age": 4, "nick": "n"}}},
+		{"adds to existing extras", map[string]any{"age": 3}, D{Extras: map[string]any{"k": 1}}, D{"", map[string]any{"k": 1, "age": 3}}},
+		{"nothing unmatched", map[string]any{"Name": "z"}, D{}, D{Name: "z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.to
+
+			if err := CopyE(tt.from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(d, tt.want) {
+				t.Fatalf("got %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+
+	type Ints struct {
+		Name   string
+		Extras map[string]int `copy:",inline"`
+	}
+
+	errTests := []struct {
+		name      string
+		from      any
+		wantField string
+		want      map[string]int
+	}{
+		{"map key", map[string]any{"Name": "x", "n": 1, "bad": []int{1}}, "bad", map[string]int{"n": 1}},
+		{"struct field", struct {
+			Name string
+			N    int
+			Bad  []int
+		}{"x", 1, []int{1}}, "Bad", map[string]int{"N": 1}},
+		{"nil value", map[string]any{"Name": "x", "n": 1, "none": nil}, "", map[string]int{"n": 1}},
+	}
+
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Ints
+
+			err := CopyE(tt.from, &d)
+
+			var fieldErr *FieldError
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if !errors.Is(err, ErrUnconvertible) || !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantField {
+				t.Fatalf("err = %v, want ErrUnconvertible for %s", err, tt.wantField)
+			}
+
+			if !reflect.DeepEqual(d.Extras, tt.want) {
+				t.Fatalf("extras = %v, want %v", d.Extras, tt.want)
+			}
+		})
+	}
+
+	setVar(t, &ZeroUnmatched, true)
+
+	d := D{Extras: map[string]any{"k": 1}}
+
+	if err := CopyE(map[string]any{"Name": "z"}, &d); err != nil || d.Extras["k"] != 1 {
+		t.Fatalf("got %+v, %v, want extras kept under ZeroUnmatched", d, err)
+	}
+}
+
+func TestInlineExtrasSpread(t *testing.T) {
+	type S struct {
+		Name   string
+		Extras map[string]any `copy:",inline"`
+	}
+
+	tests := []struct {
+		name string
+		from S
+		want map[string]any
+	}{
+		{"top level", S{"x", map[string]any{"age": 3, "tags": "a"}}, map[string]any{"Name": "x", "age": 3, "tags": "a"}},
+		{"fields win over extras", S{"x", map[string]any{"Name": "shadow"}}, map[string]any{"Name": "x"}},
+		{"nil extras", S{Name: "x"}, map[string]any{"Name": "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := map[string]any{}
+
+			if err := CopyE(tt.from, &m); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(m, tt.want) {
+				t.Fatalf("got %v, want %v", m, tt.want)
+			}
+
+			var back S
+
+			if err := CopyE(m, &back); err != nil {
+				t.Fatal(err)
+			}
+
+			if back.Name != tt.from.Name || len(back.Extras) != len(m)-1 {
+				t.Fatalf("round trip got %+v", back)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package copy
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func formatTime(t time.Time) string {
+	if TimeFormatter != nil {
+		return TimeFormatter(t)
+	}
+
+	if !KeepSourceZone {
+		t = t.In(getTimeZone())
+	}
+
+	return t.Format(getLayout())
+}
+
+func parseTime(s string) (time.Time, bool) {
+	if TimeParser != nil {
+		t, err := TimeParser(s)
+
+		return t, err == nil
+	}
+
+	if t, err := time.ParseInLocation(getLayout(), s, getTimeZone()); err == nil {
+		return t, true
+	}
+
+	if !AutoLayout {
+		return time.Time{}, false
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return unixTime(reflect.ValueOf(n)), true
+	}
+
+	if layout, ok := detectLayout(s); ok {
+		if t, err := time.ParseInLocation(layout, s, getTimeZone()); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func unixTime(v reflect.Value) time.Time {
+	unit := UnixUnit
+
+	if unit <= 0 {
+		unit = time.Second
+	}
+
+	var t time.Time
+
+	switch {
+	case v.CanFloat() && (FloatSeconds || unit == time.Second):
+		sec, frac := math.Modf(v.Float())
+		t = time.Unix(int64(sec), int64(math.Round(frac*1e9)))
+	case v.CanFloat():
+		t = time.Unix(0, int64(v.Float()*float64(unit)))
+	case unit >= time.Second:
+		t = time.Unix(toInt64(v)*int64(unit/time.Second), 0)
+	default:
+		n, per := toInt64(v), int64(time.Second/unit)
+		t = time.Unix(n/per, n%per*int64(unit))
+	}
+
+	return t.In(getTimeZone())
+}
+
+func floatSeconds(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/1e9
+}
+
+func toInt64(v reflect.Value) int64 {
+	if v.CanUint() {
+		return int64(v.Uint())
+	}
+
+	return v.Int()
+}
+
+func detectLayout(s string) (string, bool) {
+	switch {
+	case len(s) == len(time.DateOnly) && s[4] == '-' && s[7] == '-':
+		return time.DateOnly, true
+	case len(s) == len(time.TimeOnly) && s[2] == ':' && s[5] == ':':
+		return time.TimeOnly, true
+	case len(s) >= len(time.DateTime) && s[4] == '-' && s[7] == '-' && s[13] == ':':
+		if s[10] == 'T' {
+			if strings.HasSuffix(s, "Z") || strings.ContainsAny(s[19:], "+-") {
+				return time.RFC3339, true
+			}
+
+			return "2006-01-02T15:04:05", true
+		}
+
+		if s[10] == ' ' {
+			return time.DateTime, true
+		}
+	case strings.HasSuffix(s, "GMT") && strings.Contains(s, ","):
+		return time.RFC1123, true
+	}
+
+	return "", false
+}
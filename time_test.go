@@ -0,0 +1,310 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAutoLayout(t *testing.T) {
+	setVar(t, &TimeZone, "UTC")
+	setVar(t, &AutoLayout, true)
+
+	tests := []struct {
+		name string
+		in   string
+		unit time.Duration
+		want time.Time
+	}{
+		{"date only", "2024-03-04", time.Second, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"RFC3339", "2024-03-04T05:06:07+02:00", time.Second, time.Date(2024, 3, 4, 3, 6, 7, 0, time.UTC)},
+		{"unix seconds", "1700000000", time.Second, time.Unix(1700000000, 0)},
+		{"unix millis", "1700000000123", time.Millisecond, time.UnixMilli(1700000000123)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &UnixUnit, tt.unit)
+
+			var d struct{ T time.Time }
+
+			Copy(struct{ T string }{tt.in}, &d)
+
+			if !d.T.Equal(tt.want) {
+				t.Fatalf("got %v, want %v", d.T, tt.want)
+			}
+		})
+	}
+
+	setVar(t, &AutoLayout, false)
+
+	var d struct{ T time.Time }
+
+	Copy(struct{ T string }{"2024-03-04"}, &d)
+
+	if !d.T.IsZero() {
+		t.Fatalf("without AutoLayout got %v", d.T)
+	}
+}
+
+func TestUnixTimestamps(t *testing.T) {
+	tests := []struct {
+		name string
+		unit time.Duration
+		from any
+		want time.Time
+	}{
+		{"seconds", time.Second, int64(1700000000), time.Unix(1700000000, 0)},
+		{"millis", time.Millisecond, int64(1700000000123), time.UnixMilli(1700000000123)},
+		{"nanos", time.Nanosecond, int64(1700000000000000001), time.Unix(0, 1700000000000000001)},
+		{"float seconds", time.Second, 1700000000.5, time.Unix(1700000000, 500000000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &UnixUnit, tt.unit)
+
+			var tm time.Time
+
+			if err := CopyE(tt.from, &tm); err != nil {
+				t.Fatal(err)
+			}
+
+			if !tm.Equal(tt.want) {
+				t.Fatalf("got %v, want %v", tm, tt.want)
+			}
+		})
+	}
+
+	var s struct{ T time.Time }
+
+	Copy(map[string]any{"T": 10}, &s)
+
+	if s.T.Unix() != 10 {
+		t.Fatalf("map value = %v", s.T)
+	}
+}
+
+func TestTimeFormatterAndParser(t *testing.T) {
+	const layout = "2006-01-02T15:04:05.000000"
+
+	setVar(t, &TimeFormatter, func(t time.Time) string {
+		return t.UTC().Format(layout)
+	})
+
+	setVar(t, &TimeParser, func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	})
+
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+	var s string
+
+	if err := CopyE(tm, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s != "2024-01-02T03:04:05.123456" {
+		t.Fatalf("formatted %q", s)
+	}
+
+	var back time.Time
+
+	if err := CopyE(s, &back); err != nil {
+		t.Fatal(err)
+	}
+
+	if !back.Equal(tm) {
+		t.Fatalf("parsed %v, want %v", back, tm)
+	}
+}
+
+func TestNormalizeTimeZone(t *testing.T) {
+	setVar(t, &TimeZone, "Asia/Shanghai")
+
+	type E struct {
+		At time.Time
+	}
+
+	from := E{At: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name      string
+		normalize bool
+		want      string
+	}{
+		{"disabled", false, "UTC"},
+		{"enabled", true, "Asia/Shanghai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &NormalizeTimeZone, tt.normalize)
+
+			var d E
+
+			if err := CopyE(from, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.At.Location().String() != tt.want || !d.At.Equal(from.At) {
+				t.Fatalf("got %v, want zone %s", d.At, tt.want)
+			}
+
+			var p struct{ At *time.Time }
+
+			if err := CopyE(&from, &p); err != nil {
+				t.Fatal(err)
+			}
+
+			if p.At == nil || p.At.Location().String() != tt.want {
+				t.Fatalf("pointer got %v, want zone %s", p.At, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloatSeconds(t *testing.T) {
+	setVar(t, &FloatSeconds, true)
+
+	tm := time.Unix(1700000000, 123456789)
+
+	var f float64
+
+	if err := CopyE(tm, &f); err != nil {
+		t.Fatal(err)
+	}
+
+	var back time.Time
+
+	if err := CopyE(f, &back); err != nil {
+		t.Fatal(err)
+	}
+
+	if d := back.Sub(tm); d > time.Microsecond || d < -time.Microsecond {
+		t.Fatalf("round trip is off by %v", d)
+	}
+
+	var s struct{ At float32 }
+
+	if err := CopyE(struct{ At time.Time }{time.Unix(10, 5e8)}, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.At != 10.5 {
+		t.Fatalf("float32 = %v", s.At)
+	}
+
+	// floats are read as seconds whatever UnixUnit says
+
+	tests := []struct {
+		unit time.Duration
+		from float64
+		want int64
+	}{
+		{time.Millisecond, 1.5, 1500000000},
+		{time.Second, -1.25, -1250000000},
+	}
+
+	for _, tt := range tests {
+		setVar(t, &UnixUnit, tt.unit)
+
+		var got time.Time
+
+		if err := CopyE(tt.from, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		if got.UnixNano() != tt.want {
+			t.Fatalf("%v in %v = %d ns, want %d", tt.from, tt.unit, got.UnixNano(), tt.want)
+		}
+	}
+}
+
+func TestKeepSourceZone(t *testing.T) {
+	setVar(t, &TimeZone, "Asia/Shanghai")
+
+	ny, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Skip(err)
+	}
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, ny)
+
+	tests := []struct {
+		name string
+		keep bool
+		from time.Time
+		want string
+	}{
+		{"converted", false, at, "2024-01-02 16:04:05"},
+		{"source zone", true, at, "2024-01-02 03:04:05"},
+		{"source zone utc", true, at.UTC(), "2024-01-02 08:04:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &KeepSourceZone, tt.keep)
+
+			var d struct{ T string }
+
+			if err := CopyE(struct{ T time.Time }{tt.from}, &d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.T != tt.want {
+				t.Fatalf("got %q, want %q", d.T, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackTimeZone(t *testing.T) {
+	setVar(t, &TimeZone, "Nowhere/Bad")
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name     string
+		fallback *time.Location
+		strict   bool
+		from     any
+		to       any
+		want     any
+		wantErr  error
+	}{
+		{"utc fallback", time.UTC, false, at, new(string), str("2024-01-02 03:04:05"), nil},
+		{"custom fallback", time.FixedZone("X", 3600), false, at, new(string), str("2024-01-02 04:04:05"), nil},
+		{"strict format", time.UTC, true, struct{ T time.Time }{at}, &struct{ T string }{}, &struct{ T string }{}, ErrTimeZone},
+		{"strict parse", time.UTC, true, struct{ T string }{"2024-01-02 03:04:05"}, &struct{ T time.Time }{}, &struct{ T time.Time }{}, ErrTimeZone},
+		{"strict unix", time.UTC, true, int64(1), new(time.Time), new(time.Time), ErrTimeZone},
+		{"strict without times", time.UTC, true, struct{ N int }{1}, &struct{ N string }{}, &struct{ N string }{"1"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVar(t, &FallbackTimeZone, tt.fallback)
+			setVar(t, &StrictTimeZone, tt.strict)
+
+			err := CopyE(tt.from, tt.to)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(tt.to, tt.want) {
+				t.Fatalf("got %v, want %v", reflect.ValueOf(tt.to).Elem(), reflect.ValueOf(tt.want).Elem())
+			}
+		})
+	}
+
+	if err := CheckTimeZone(); !errors.Is(err, ErrTimeZone) {
+		t.Fatalf("err = %v, want ErrTimeZone", err)
+	}
+}
@@ -0,0 +1,79 @@
+package copy
+
+import (
+	"errors"
+	"reflect"
+)
+
+func FromValues(values map[string][]string, to any) error {
+	toValue := reflect.ValueOf(to)
+
+	if !toValue.IsValid() || toValue.Kind() != reflect.Pointer || toValue.IsNil() {
+		return ErrInvalidDestination
+	}
+
+	toValue = indirectValue(toValue)
+
+	if toValue.Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	toType := toValue.Type()
+
+	var errs []error
+
+	for i := 0; i < toType.NumField(); i++ {
+		toField := toType.Field(i)
+		toTag := parseTag(toField, "form")
+
+		if toTag.ignore {
+			continue
+		}
+
+		v, ok := values[toTag.name]
+
+		if !ok || len(v) == 0 {
+			continue
+		}
+
+		toFieldValue := toValue.Field(i)
+
+		if !toFieldValue.CanSet() {
+			continue
+		}
+
+		if toField.Type.Kind() == reflect.Pointer && toFieldValue.IsNil() {
+			toFieldValue.Set(reflect.New(indirectType(toField.Type)))
+		}
+
+		fieldValue := indirectValue(toFieldValue)
+
+		if fieldValue.Kind() == reflect.Slice && !isBytes(fieldValue.Type()) {
+			s := reflect.MakeSlice(fieldValue.Type(), 0, len(v))
+
+			for _, item := range v {
+				e := reflect.New(fieldValue.Type().Elem()).Elem()
+
+				ok, err := copyValue(reflect.ValueOf(item), e)
+
+				if err != nil {
+					errs = append(errs, &FieldError{Field: toField.Name, Err: err})
+				}
+
+				if ok {
+					s = reflect.Append(s, e)
+				}
+			}
+
+			fieldValue.Set(s)
+
+			continue
+		}
+
+		if _, err := copyValue(reflect.ValueOf(v[0]), toFieldValue); err != nil {
+			errs = append(errs, &FieldError{Field: toField.Name, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromValues(t *testing.T) {
+	type Form struct {
+		Name   string   `form:"name"`
+		Age    *int     `form:"age"`
+		Tags   []string `form:"tag"`
+		IDs    []int    `form:"id"`
+		Secret string   `form:"-"`
+	}
+
+	var f Form
+
+	err := FromValues(map[string][]string{
+		"name":   {"ann", "ignored"},
+		"age":    {"31"},
+		"tag":    {"a", "b"},
+		"id":     {"1", "2", "3"},
+		"Secret": {"x"},
+	}, &f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Name != "ann" || f.Age == nil || *f.Age != 31 || f.Secret != "" {
+		t.Fatalf("got %+v", f)
+	}
+
+	if !reflect.DeepEqual(f.Tags, []string{"a", "b"}) || !reflect.DeepEqual(f.IDs, []int{1, 2, 3}) {
+		t.Fatalf("got Tags %v, IDs %v", f.Tags, f.IDs)
+	}
+}
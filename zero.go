@@ -0,0 +1,33 @@
+package copy
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	zeroChecksMu sync.RWMutex
+	zeroChecks   = map[reflect.Type]func(reflect.Value) bool{}
+)
+
+func RegisterIsZero(reflectType reflect.Type, isZero func(reflect.Value) bool) {
+	zeroChecksMu.Lock()
+	defer zeroChecksMu.Unlock()
+
+	if isZero == nil {
+		delete(zeroChecks, reflectType)
+
+		return
+	}
+
+	zeroChecks[reflectType] = isZero
+}
+
+func zeroCheck(reflectType reflect.Type) (func(reflect.Value) bool, bool) {
+	zeroChecksMu.RLock()
+	defer zeroChecksMu.RUnlock()
+
+	isZero, ok := zeroChecks[reflectType]
+
+	return isZero, ok
+}
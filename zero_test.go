@@ -0,0 +1,60 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type uuid [16]byte
+
+var nilUUID = uuid{0xff}
+
+func TestRegisterIsZero(t *testing.T) {
+	RegisterIsZero(reflect.TypeOf(uuid{}), func(v reflect.Value) bool {
+		return v.Interface().(uuid) == nilUUID || v.IsZero()
+	})
+
+	t.Cleanup(func() {
+		RegisterIsZero(reflect.TypeOf(uuid{}), nil)
+	})
+
+	type S struct {
+		ID uuid
+		N  int
+	}
+
+	tests := []struct {
+		name string
+		to   S
+		from S
+		opt  Option
+		want S
+	}{
+		{"only non-zero skips registered zero", S{ID: uuid{1}, N: 1}, S{ID: nilUUID, N: 2}, OnlyNonZero(), S{ID: uuid{1}, N: 2}},
+		{"only non-zero skips plain zero", S{ID: uuid{1}, N: 1}, S{N: 2}, OnlyNonZero(), S{ID: uuid{1}, N: 2}},
+		{"only non-zero copies set value", S{ID: uuid{1}}, S{ID: uuid{2}}, OnlyNonZero(), S{ID: uuid{2}}},
+		{"keep existing overwrites registered zero", S{ID: nilUUID, N: 1}, S{ID: uuid{2}, N: 2}, KeepExisting(), S{ID: uuid{2}, N: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := tt.to
+
+			if err := MergeWith(&to, []any{tt.from}, tt.opt); err != nil {
+				t.Fatal(err)
+			}
+
+			if to != tt.want {
+				t.Fatalf("got %v, want %v", to, tt.want)
+			}
+		})
+	}
+
+	RegisterIsZero(reflect.TypeOf(uuid{}), nil)
+
+	to := S{ID: uuid{1}}
+
+	if err := MergeWith(&to, []any{S{ID: nilUUID}}, OnlyNonZero()); err != nil || to.ID != nilUUID {
+		t.Fatalf("got %v, %v after unregistering", to, err)
+	}
+}